@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// agendaDay holds the open tasks due on a single day
+type agendaDay struct {
+	date  time.Time
+	tasks []models.Task
+}
+
+// handleAgenda handles the agenda command
+func (a *App) handleAgenda(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	days := 14
+	if value, ok := parsedArgs["days"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of days: %s", value)
+		}
+		days = n
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	agenda := buildAgenda(tasks, a.workflow(), today, days)
+
+	if len(agenda) == 0 {
+		fmt.Printf("No tasks due in the next %d days\n", days)
+		return nil
+	}
+
+	for i, day := range agenda {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", day.date.Format("Mon 2006-01-02"), agendaNote(day.date, today))
+		for _, task := range day.tasks {
+			fmt.Printf("  %s\n", task)
+		}
+	}
+
+	return nil
+}
+
+// buildAgenda groups the open tasks that are overdue or due within the next
+// days by due date, oldest first
+func buildAgenda(tasks []models.Task, workflow *models.Workflow, today time.Time, days int) []agendaDay {
+	end := today.AddDate(0, 0, days)
+	byDay := make(map[time.Time][]models.Task)
+
+	for _, task := range tasks {
+		if task.Due == nil || workflow.IsClosed(task.Status) {
+			continue
+		}
+		due := startOfDay(*task.Due)
+		if !due.Before(end) {
+			continue
+		}
+		byDay[due] = append(byDay[due], task)
+	}
+
+	agenda := make([]agendaDay, 0, len(byDay))
+	for date, dayTasks := range byDay {
+		sort.Slice(dayTasks, func(i, j int) bool { return dayTasks[i].ID < dayTasks[j].ID })
+		agenda = append(agenda, agendaDay{date: date, tasks: dayTasks})
+	}
+	sort.Slice(agenda, func(i, j int) bool { return agenda[i].date.Before(agenda[j].date) })
+
+	return agenda
+}
+
+// agendaNote describes a due date relative to today
+func agendaNote(date, today time.Time) string {
+	switch days := daysBetween(today, date); {
+	case days == 0:
+		return "(today)"
+	case days == 1:
+		return "(tomorrow)"
+	case days < 0:
+		return fmt.Sprintf("(overdue by %d days)", -days)
+	default:
+		return fmt.Sprintf("(in %d days)", days)
+	}
+}
+
+// handleCalendar handles the calendar command
+func (a *App) handleCalendar(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	month := startOfDay(time.Now())
+	if value, ok := parsedArgs["month"]; ok {
+		parsed, err := time.ParseInLocation("2006-01", value, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid month %q, expected YYYY-MM", value)
+		}
+		month = parsed
+	}
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	printCalendar(month, countDue(tasks, a.workflow(), month))
+	return nil
+}
+
+// countDue returns the number of open tasks due on each day of the month
+func countDue(tasks []models.Task, workflow *models.Workflow, month time.Time) map[int]int {
+	counts := make(map[int]int)
+
+	for _, task := range tasks {
+		if task.Due == nil || workflow.IsClosed(task.Status) {
+			continue
+		}
+		due := task.Due.Local()
+		if due.Year() == month.Year() && due.Month() == month.Month() {
+			counts[due.Day()]++
+		}
+	}
+
+	return counts
+}
+
+// printCalendar prints a Monday-first month grid with the number of tasks due
+// next to each day
+func printCalendar(month time.Time, counts map[int]int) {
+	const cell = 7
+
+	title := month.Format("January 2006")
+	fmt.Printf("%*s\n", (7*cell+len(title))/2, title)
+
+	var line strings.Builder
+	for _, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		fmt.Fprintf(&line, "%-*s", cell, name)
+	}
+	fmt.Println(strings.TrimRight(line.String(), " "))
+
+	line.Reset()
+	line.WriteString(strings.Repeat(" ", cell*((int(month.Weekday())+6)%7)))
+
+	daysInMonth := month.AddDate(0, 1, -1).Day()
+	for day := 1; day <= daysInMonth; day++ {
+		text := strconv.Itoa(day)
+		if n := counts[day]; n > 0 {
+			text += fmt.Sprintf("(%d)", n)
+		}
+		fmt.Fprintf(&line, "%-*s", cell, text)
+
+		if month.AddDate(0, 0, day-1).Weekday() == time.Sunday || day == daysInMonth {
+			fmt.Println(strings.TrimRight(line.String(), " "))
+			line.Reset()
+		}
+	}
+
+	fmt.Println("\n(n) = open tasks due that day")
+}
+
+// daysBetween returns the number of calendar days from one day to another
+func daysBetween(from, to time.Time) int {
+	// Round whole days so DST changes do not shift the result
+	return int(math.Round(startOfDay(to).Sub(startOfDay(from)).Hours() / 24))
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestBuildAgenda(t *testing.T) {
+	today := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	due := func(days int) *time.Time {
+		date := today.AddDate(0, 0, days)
+		return &date
+	}
+
+	tasks := []models.Task{
+		{ID: 1, Status: models.StatusTodo, Due: due(2)},
+		{ID: 2, Status: models.StatusTodo, Due: due(-3)},
+		{ID: 3, Status: models.StatusInProgress, Due: due(2)},
+		{ID: 4, Status: models.StatusDone, Due: due(1)},
+		{ID: 5, Status: models.StatusTodo, Due: due(14)},
+		{ID: 6, Status: models.StatusTodo},
+	}
+
+	agenda := buildAgenda(tasks, models.DefaultWorkflow(), today, 14)
+
+	if len(agenda) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(agenda))
+	}
+
+	if !agenda[0].date.Equal(*due(-3)) || len(agenda[0].tasks) != 1 || agenda[0].tasks[0].ID != 2 {
+		t.Errorf("Expected overdue task 2 first, got %v", agenda[0])
+	}
+
+	if len(agenda[1].tasks) != 2 || agenda[1].tasks[0].ID != 1 || agenda[1].tasks[1].ID != 3 {
+		t.Errorf("Expected tasks 1 and 3 on the second day, got %v", agenda[1].tasks)
+	}
+}
+
+func TestAgendaNote(t *testing.T) {
+	today := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		days     int
+		expected string
+	}{
+		{0, "(today)"},
+		{1, "(tomorrow)"},
+		{4, "(in 4 days)"},
+		{-2, "(overdue by 2 days)"},
+	}
+
+	for _, tt := range tests {
+		if got := agendaNote(today.AddDate(0, 0, tt.days), today); got != tt.expected {
+			t.Errorf("agendaNote(%d): expected %q, got %q", tt.days, tt.expected, got)
+		}
+	}
+}
+
+func TestCountDue(t *testing.T) {
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	date := func(m time.Month, d int) *time.Time {
+		due := time.Date(2024, m, d, 0, 0, 0, 0, time.Local)
+		return &due
+	}
+
+	tasks := []models.Task{
+		{ID: 1, Status: models.StatusTodo, Due: date(time.March, 5)},
+		{ID: 2, Status: models.StatusInProgress, Due: date(time.March, 5)},
+		{ID: 3, Status: models.StatusDone, Due: date(time.March, 5)},
+		{ID: 4, Status: models.StatusTodo, Due: date(time.April, 5)},
+		{ID: 5, Status: models.StatusTodo, Due: date(time.March, 31)},
+	}
+
+	counts := countDue(tasks, models.DefaultWorkflow(), month)
+
+	if counts[5] != 2 {
+		t.Errorf("Expected 2 tasks due on the 5th, got %d", counts[5])
+	}
+	if counts[31] != 1 {
+		t.Errorf("Expected 1 task due on the 31st, got %d", counts[31])
+	}
+	if len(counts) != 2 {
+		t.Errorf("Expected 2 days with tasks due, got %v", counts)
+	}
+}
+
+func TestHandleAgendaAndCalendar(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	if err := app.handleAdd([]string{"Test Task"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if err := app.handleUpdate([]string{"1", "--due", time.Now().Format(models.DateLayout)}); err != nil {
+		t.Fatalf("Failed to set due date: %v", err)
+	}
+
+	for _, args := range [][]string{{}, {"--days", "3"}} {
+		if err := app.handleAgenda(args); err != nil {
+			t.Errorf("Expected no error for agenda %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleAgenda([]string{"--days", "0"}); err == nil {
+		t.Error("Expected error for invalid days, got nil")
+	}
+
+	for _, args := range [][]string{{}, {"--month", "2024-02"}} {
+		if err := app.handleCalendar(args); err != nil {
+			t.Errorf("Expected no error for calendar %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleCalendar([]string{"--month", "March"}); err == nil {
+		t.Error("Expected error for invalid month, got nil")
+	}
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+)
+
+// handleBackup handles the backup command
+func (a *App) handleBackup(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Subcommand is required (create, list, restore)")
+		return nil
+	}
+
+	if a.backups == nil {
+		return fmt.Errorf("the task store does not support backups")
+	}
+
+	switch args[0] {
+	case "create":
+		backup, err := a.backups.Create()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created backup %s\n", backup.Name)
+	case "list":
+		return a.listBackups()
+	case "restore":
+		name := parseArgs(args[1:])["main"]
+		if name == "" {
+			fmt.Println("Error: Backup name is required")
+			return nil
+		}
+
+		current, err := a.backups.Restore(name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Restored backup %s\n", name)
+		if current.Name != "" {
+			fmt.Printf("The replaced task store was backed up as %s\n", current.Name)
+		}
+	default:
+		fmt.Printf("Unknown backup subcommand: %s\n", args[0])
+	}
+
+	return nil
+}
+
+// listBackups prints the backups of the task store, newest first
+func (a *App) listBackups() error {
+	backups, err := a.backups.List()
+	if err != nil {
+		return err
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("No backups found.")
+		return nil
+	}
+
+	fmt.Println(a.header(fmt.Sprintf("Backups in %s", a.backups.Dir())))
+	for _, backup := range backups {
+		fmt.Printf("%s  %s\n", backup.Name, backup.Time.Format("2006-01-02 15:04:05"))
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleBackup(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "backup-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	jsonStorage, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	backups := storage.NewBackups(filePath, filepath.Join(tempDir, "backups"), storage.BackupPolicy{Count: 5}, milestonesPath(filePath))
+	app := &App{
+		storage:   storage.NewBackupStorage(jsonStorage, backups),
+		backups:   backups,
+		storePath: filePath,
+	}
+
+	// Test argument validation
+	if err := app.handleBackup([]string{}); err != nil {
+		t.Errorf("Expected no error for missing subcommand, got %v", err)
+	}
+
+	if err := app.handleBackup([]string{"restore"}); err != nil {
+		t.Errorf("Expected no error for missing backup name, got %v", err)
+	}
+
+	// Test creating and listing backups
+	if err := app.handleBackup([]string{"create"}); err != nil {
+		t.Fatalf("Expected no error when creating backup, got %v", err)
+	}
+
+	if _, err := app.storage.AddTask(models.Task{Title: "Mistake", Status: models.StatusTodo}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	if err := app.handleBackup([]string{"list"}); err != nil {
+		t.Errorf("Expected no error when listing backups, got %v", err)
+	}
+
+	list, err := backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("Expected a manual and an automatic backup, got %d", len(list))
+	}
+
+	// Test restoring the state before the task was added
+	if err := app.handleBackup([]string{"restore", list[0].Name}); err != nil {
+		t.Fatalf("Expected no error when restoring backup, got %v", err)
+	}
+
+	tasks, err := app.storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != 0 {
+		t.Errorf("Expected no tasks after restore, got %d", len(tasks))
+	}
+
+	if err := app.handleBackup([]string{"restore", "unknown"}); err == nil {
+		t.Error("Expected error when restoring unknown backup, got nil")
+	}
+}
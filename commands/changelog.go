@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// changelogSections lists the Keep a Changelog sections in their canonical order
+var changelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// changelogLabels maps common labels onto changelog sections; other labels
+// are listed under "Changed"
+var changelogLabels = map[string]string{
+	"feature":     "Added",
+	"enhancement": "Added",
+	"deprecation": "Deprecated",
+	"deprecated":  "Deprecated",
+	"removal":     "Removed",
+	"removed":     "Removed",
+	"bug":         "Fixed",
+	"bugfix":      "Fixed",
+	"fix":         "Fixed",
+	"security":    "Security",
+}
+
+// handleChangelog handles the changelog command
+func (a *App) handleChangelog(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	value, ok := parsedArgs["since"]
+	if !ok {
+		fmt.Println("Error: --since <date|milestone> is required")
+		return nil
+	}
+
+	since, err := a.changelogSince(value)
+	if err != nil {
+		return err
+	}
+
+	status, err := a.changelogStatus(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	sections := groupChangelog(tasks, a.workflow(), status, since)
+
+	version := parsedArgs["version"]
+	if version == "" {
+		fmt.Println("## [Unreleased]")
+	} else {
+		fmt.Printf("## [%s] - %s\n", version, time.Now().Format(models.DateLayout))
+	}
+
+	if len(sections) == 0 {
+		fmt.Printf("\nNo tasks reached %s since %s\n", status, since.Format(models.DateLayout))
+		return nil
+	}
+
+	for _, section := range changelogSections {
+		entries := sections[section]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Printf("\n### %s\n\n", section)
+		for _, task := range entries {
+			fmt.Printf("- %s (#%d)\n", task.Title, task.ID)
+		}
+	}
+
+	return nil
+}
+
+// changelogSince resolves the start of the changelog window: the end of a
+// milestone with that name (the previous release), or a date
+func (a *App) changelogSince(value string) (time.Time, error) {
+	if a.milestones != nil {
+		if milestone, err := a.milestones.GetMilestone(value); err == nil {
+			switch {
+			case milestone.End != nil:
+				return milestone.End.AddDate(0, 0, 1), nil
+			case milestone.Start != nil:
+				return *milestone.Start, nil
+			default:
+				return milestone.CreatedAt, nil
+			}
+		}
+	}
+
+	return parseSince(value, time.Now())
+}
+
+// changelogStatus returns the status marking a task as shipped: --status,
+// or "done" if the workflow has it, or else the first closed status
+func (a *App) changelogStatus(parsedArgs map[string]string) (models.Status, error) {
+	workflow := a.workflow()
+
+	if value, ok := parsedArgs["status"]; ok {
+		if !workflow.IsValid(models.Status(value)) {
+			return "", fmt.Errorf("invalid status: %s", value)
+		}
+		return models.Status(value), nil
+	}
+
+	if workflow.IsValid(models.StatusDone) {
+		return models.StatusDone, nil
+	}
+
+	status, ok := workflow.FirstInCategory(models.CategoryClosed)
+	if !ok {
+		return "", fmt.Errorf("workflow has no closed status")
+	}
+	return status, nil
+}
+
+// groupChangelog groups the tasks that reached status since the given time by section
+func groupChangelog(tasks []models.Task, workflow *models.Workflow, status models.Status, since time.Time) map[string][]models.Task {
+	sections := make(map[string][]models.Task)
+
+	for _, task := range tasks {
+		if task.Status != status {
+			continue
+		}
+		completed, ok := task.CompletedAt(workflow)
+		if !ok || completed.Before(since) {
+			continue
+		}
+
+		section, ok := changelogLabels[strings.ToLower(task.Label)]
+		if !ok {
+			section = "Changed"
+		}
+		sections[section] = append(sections[section], task)
+	}
+
+	return sections
+}
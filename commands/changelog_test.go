@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestGroupChangelog(t *testing.T) {
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	before := since.Add(-48 * time.Hour)
+	after := since.Add(2 * time.Hour)
+	workflow := models.DefaultWorkflow()
+
+	done := func(id int, label string, at time.Time) models.Task {
+		task := models.Task{ID: id, Label: label, Status: models.StatusTodo, CreatedAt: before, UpdatedAt: before}
+		task.SetStatus(models.StatusDone, at)
+		return task
+	}
+
+	open := models.Task{ID: 5, Label: "feature", Status: models.StatusTodo, CreatedAt: after, UpdatedAt: after}
+
+	tasks := []models.Task{
+		done(1, "feature", after),
+		done(2, "Bug", after),
+		done(3, "chore", after),
+		done(4, "feature", before),
+		open,
+	}
+
+	sections := groupChangelog(tasks, workflow, models.StatusDone, since)
+
+	if len(sections["Added"]) != 1 || sections["Added"][0].ID != 1 {
+		t.Errorf("Expected task 1 under Added, got %v", sections["Added"])
+	}
+
+	if len(sections["Fixed"]) != 1 || sections["Fixed"][0].ID != 2 {
+		t.Errorf("Expected task 2 under Fixed, got %v", sections["Fixed"])
+	}
+
+	if len(sections["Changed"]) != 1 || sections["Changed"][0].ID != 3 {
+		t.Errorf("Expected task 3 under Changed, got %v", sections["Changed"])
+	}
+}
+
+func TestChangelogSince(t *testing.T) {
+	milestones := storage.NewMockMilestoneStorage()
+	end := time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)
+	if err := milestones.SaveMilestone(models.Milestone{Name: "v1.0", End: &end}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	app := &App{
+		storage:    storage.NewMockStorage(),
+		milestones: milestones,
+	}
+
+	since, err := app.changelogSince("v1.0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := end.AddDate(0, 0, 1); !since.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, since)
+	}
+
+	since, err = app.changelogSince("2024-03-01")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local); !since.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, since)
+	}
+
+	if _, err := app.changelogSince("v2.0"); err == nil {
+		t.Error("Expected error for unknown milestone, got nil")
+	}
+}
+
+func TestHandleChangelog(t *testing.T) {
+	app := &App{
+		storage:    storage.NewMockStorage(),
+		milestones: storage.NewMockMilestoneStorage(),
+	}
+
+	if err := app.handleAdd([]string{"Test Task", "--label", "feature"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if err := app.handleUpdate([]string{"1", "--status", "done"}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	for _, args := range [][]string{{}, {"--since", "today"}, {"--since", "7d", "--version", "1.1.0"}} {
+		if err := app.handleChangelog(args); err != nil {
+			t.Errorf("Expected no error for changelog %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleChangelog([]string{"--since", "today", "--status", "shipped"}); err == nil {
+		t.Error("Expected error for unknown status, got nil")
+	}
+}
@@ -2,45 +2,71 @@ package commands
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/formats"
+	"github.com/mstgnz/cli-task-manager/models"
 	"github.com/mstgnz/cli-task-manager/storage"
 )
 
 // App represents the CLI application
 type App struct {
-	storage storage.Storage
+	storage      storage.Storage
+	milestones   storage.MilestoneStorage
+	storePath    string
+	storeBackend string
+	backups      *storage.Backups
+	cipher       *storage.Cipher
+	context      string
+	projectStore bool
+	config       *config.Config
 }
 
-// NewApp creates a new CLI application
-func NewApp() (*App, error) {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	// Create data directory in user's home directory
-	dataDir := filepath.Join(homeDir, ".cli-task-manager")
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create data directory: %w", err)
-	}
+// storelessCommands run without opening a task store, so that they keep
+// working when the store cannot be opened, e.g. without its key
+var storelessCommands = map[string]bool{
+	"config":   true,
+	"context":  true,
+	"init":     true,
+	"statuses": true,
+	"storage":  true,
+	"help":     true,
+}
 
-	// Create JSON storage
-	jsonStorage, err := storage.NewJSONStorage(filepath.Join(dataDir, "tasks.json"))
+// NewApp creates a new CLI application. The task store is opened by Run,
+// once the global flags selecting it are known.
+func NewApp() (*App, error) {
+	// Load configuration files and environment overrides
+	cfg, err := config.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create JSON storage: %w", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	return &App{
-		storage: jsonStorage,
+		config: cfg,
 	}, nil
 }
 
+// workflow returns the configured status workflow
+func (a *App) workflow() *models.Workflow {
+	return a.settings().ResolveWorkflow()
+}
+
+// settings returns the effective configuration, falling back to the
+// defaults when the app was created without one
+func (a *App) settings() *config.Config {
+	if a.config == nil {
+		return config.Default()
+	}
+	return a.config
+}
+
 // Run executes the CLI application with the given arguments
 func (a *App) Run(args []string) error {
+	// Global flags select the task store and may appear anywhere
+	args, opts := extractStoreOptions(args)
+
 	if len(args) < 2 {
 		a.printUsage()
 		return nil
@@ -48,6 +74,13 @@ func (a *App) Run(args []string) error {
 
 	command := args[1]
 
+	// Open the selected store unless the command does not use one
+	if !storelessCommands[command] && (a.storage == nil || opts != (storeOptions{})) {
+		if err := a.openStore(opts); err != nil {
+			return err
+		}
+	}
+
 	switch command {
 	case "add":
 		return a.handleAdd(args[2:])
@@ -59,6 +92,54 @@ func (a *App) Run(args []string) error {
 		return a.handleFilter(args[2:])
 	case "remove":
 		return a.handleRemove(args[2:])
+	case "show":
+		return a.handleShow(args[2:])
+	case "report":
+		return a.handleReport(args[2:])
+	case "milestone":
+		return a.handleMilestone(args[2:])
+	case "stats":
+		return a.handleStats(args[2:])
+	case "standup":
+		return a.handleStandup(args[2:])
+	case "changelog":
+		return a.handleChangelog(args[2:])
+	case "agenda":
+		return a.handleAgenda(args[2:])
+	case "calendar":
+		return a.handleCalendar(args[2:])
+	case "export":
+		return a.handleExport(args[2:])
+	case "import":
+		return a.handleImport(args[2:])
+	case "start":
+		return a.handleStart(args[2:])
+	case "stop":
+		return a.handleStop(args[2:])
+	case "log":
+		return a.handleLog(args[2:])
+	case "time":
+		return a.handleTime(args[2:])
+	case "config":
+		return a.handleConfig(args[2:])
+	case "init":
+		return a.handleInit(args[2:])
+	case "context":
+		return a.handleContext(args[2:])
+	case "migrate-storage":
+		return a.handleMigrateStorage(args[2:])
+	case "storage":
+		return a.handleStorage(args[2:])
+	case "backup":
+		return a.handleBackup(args[2:])
+	case "doctor":
+		return a.handleDoctor(args[2:])
+	case "encrypt":
+		return a.handleEncrypt(args[2:])
+	case "decrypt":
+		return a.handleDecrypt(args[2:])
+	case "statuses":
+		return a.handleStatuses(args[2:])
 	case "help":
 		a.printUsage()
 		return nil
@@ -76,15 +157,79 @@ func (a *App) printUsage() {
 	fmt.Println("  issue-tracker <command> [arguments]")
 	fmt.Println("\nCommands:")
 	fmt.Println("  add <title> --label <label>                Add a new task")
-	fmt.Println("  list                                       List all tasks")
+	fmt.Println("  add <title> --estimate <2h|3pt>            Add a task with an estimate")
+	fmt.Println("  list [--output text|json]                  List all tasks")
+	fmt.Println("  list --sort <key> [--reverse]              List tasks sorted by a field or custom field")
+	fmt.Println("  list --format <template>                   List tasks using a Go template")
 	fmt.Println("  update <id> --status <status>              Update task status")
+	fmt.Println("  update <id> --set <field>=<value>          Set a custom field (repeatable)")
+	fmt.Println("  update <id> --estimate <2h|3pt>            Set the estimate (empty to clear)")
+	fmt.Println("  update <id> --due <YYYY-MM-DD>             Set the due date (empty to clear)")
+	fmt.Println("  update <id> --milestone <name>             Assign the task to a milestone (empty to clear)")
+	fmt.Println("  update <id> --recur <rule>                 Repeat the task: daily, weekly:mon,thu, monthly:15, every:3d")
 	fmt.Println("  filter --label <label>                     Filter tasks by label")
+	fmt.Println("  filter --field <field>=<value>             Filter tasks by custom field")
+	fmt.Println("  filter --milestone <name>                  Filter tasks by milestone")
 	fmt.Println("  remove <id>                                Remove a task")
+	fmt.Println("  show <id>                                  Show task details")
+	fmt.Println("  report estimates [--over]                  Compare estimates with tracked time")
+	fmt.Println("  report burndown [--milestone <name>] [--from <date>] [--to <date>]")
+	fmt.Println("                                             Chart open tasks per day")
+	fmt.Println("  report throughput [--weeks <n>]            Chart tasks completed per week")
+	fmt.Println("  stats [--stale <days>] [--output json]     Show task statistics")
+	fmt.Println("  standup [--since <when>]                   Print tasks completed, started and created as markdown")
+	fmt.Println("  changelog --since <when|milestone> [--version <v>]")
+	fmt.Println("                                             Print a Keep a Changelog section of done tasks")
+	fmt.Println("  agenda [--days <n>]                        List overdue tasks and tasks due in the next n days (default 14)")
+	fmt.Println("  calendar [--month <YYYY-MM>]               Show a month grid with the number of tasks due per day")
+	fmt.Println("  export --format <format> [--file <path>] [--group status|label]")
+	fmt.Println("                                             Export tasks (--group applies to markdown)")
+	fmt.Println("  import --format <format> <file|-> [--map <column>=<task column>] [--dry-run]")
+	fmt.Println("                                             Import tasks as new tasks")
+	fmt.Println("  milestone create <name> [--start <date>] [--end <date>] [--description <text>]")
+	fmt.Println("                                             Create a milestone")
+	fmt.Println("  milestone list                             List milestones with their completion")
+	fmt.Println("  milestone show <name>                      Show a milestone and its open tasks")
+	fmt.Println("  milestone delete <name>                    Delete a milestone and unassign its tasks")
+	fmt.Println("  start <id>                                 Start a timer on a task and mark it in progress")
+	fmt.Println("  stop [<id>]                                Stop the running timer")
+	fmt.Println("  log <id> <duration> [--date <YYYY-MM-DD>]  Record time spent on a task, e.g. 1h30m")
+	fmt.Println("  time [--by task|label|day] [--since <date>]")
+	fmt.Println("                                             Show tracked time per task, label and day")
+	fmt.Println("  init                                       Create a task store for the current project")
+	fmt.Println("  statuses                                   List workflow statuses and allowed transitions")
+	fmt.Println("  migrate-storage --from <backend> --to <backend> [--path <file>]")
+	fmt.Println("                                             Convert the task store to another backend and switch to it")
+	fmt.Println("  storage migrate --from <uri> --to <uri> [--force] [--decrypt]")
+	fmt.Println("                                             Copy all tasks between two stores given as <backend>://<path>")
+	fmt.Println("  backup create                              Back up the task store")
+	fmt.Println("  backup list                                List the backups of the task store, newest first")
+	fmt.Println("  backup restore <name>                      Replace the task store with a backup")
+	fmt.Println("  doctor [--fix]                             Check the task store for problems and repair what it safely can")
+	fmt.Println("  encrypt [--key-file <file>]                Encrypt the task store with a passphrase or a key file")
+	fmt.Println("  decrypt                                    Decrypt the task store")
+	fmt.Println("  context create <name> [--path <file>] [--backend <backend>]")
+	fmt.Println("                                             Create a named context")
+	fmt.Println("  context use <name>                         Switch to a context (\"default\" for the global store)")
+	fmt.Println("  context list                               List contexts")
+	fmt.Println("  context delete <name>                      Delete a context (its tasks file is kept)")
+	fmt.Println("  config list                                Show the effective configuration")
+	fmt.Println("  config get <key>                           Show a configuration value")
+	fmt.Println("  config set <key> <value> [--local]         Change a configuration value")
 	fmt.Println("  help                                       Show this help message")
+	fmt.Println("\nGlobal flags:")
+	fmt.Println("  --global                                   Use the global task store instead of the project store")
+	fmt.Println("  --context <name>                           Run a single command against another context")
+	fmt.Println("\n<when> is today, yesterday, a YYYY-MM-DD date or a duration such as 3d or 36h")
+	fmt.Printf("<format> is one of: %s\n", strings.Join(formats.Names(), ", "))
+	fmt.Printf("<backend> is one of: %s\n", strings.Join(storage.Backends(), ", "))
 	fmt.Println("\nExamples:")
 	fmt.Println("  issue-tracker add \"Create API documentation\" --label feature")
 	fmt.Println("  issue-tracker update 1 --status in-progress")
 	fmt.Println("  issue-tracker filter --label bug")
+	fmt.Println("  issue-tracker config set default_label bug")
+	fmt.Println("  issue-tracker list --context work")
+	fmt.Println("  issue-tracker update 1 --set customer=Acme --set points=3")
 }
 
 // parseArgs parses command line arguments into a map
@@ -108,3 +253,34 @@ func parseArgs(args []string) map[string]string {
 
 	return result
 }
+
+// positionalArgs returns the arguments that are neither flags nor flag values
+func positionalArgs(args []string) []string {
+	var result []string
+
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "--") {
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+			}
+			continue
+		}
+		result = append(result, args[i])
+	}
+
+	return result
+}
+
+// flagValues returns every value given for a repeatable flag
+func flagValues(args []string, name string) []string {
+	var values []string
+
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == "--"+name && !strings.HasPrefix(args[i+1], "--") {
+			values = append(values, args[i+1])
+			i++
+		}
+	}
+
+	return values
+}
@@ -63,6 +63,28 @@ func TestParseArgs(t *testing.T) {
 	}
 }
 
+func TestFlagValues(t *testing.T) {
+	args := []string{"1", "--set", "a=1", "--label", "bug", "--set", "b=2", "--set"}
+
+	values := flagValues(args, "set")
+	if len(values) != 2 || values[0] != "a=1" || values[1] != "b=2" {
+		t.Errorf("Expected [a=1 b=2], got %v", values)
+	}
+
+	if values := flagValues(args, "missing"); len(values) != 0 {
+		t.Errorf("Expected no values, got %v", values)
+	}
+}
+
+func TestPositionalArgs(t *testing.T) {
+	args := []string{"set", "output", "--local", "json"}
+
+	positional := positionalArgs(args)
+	if len(positional) != 2 || positional[0] != "set" || positional[1] != "output" {
+		t.Errorf("Expected [set output], got %v", positional)
+	}
+}
+
 func TestNewApp(t *testing.T) {
 	// This is a simple test to ensure NewApp doesn't crash
 	// In a real test, we might mock the file system
@@ -75,8 +97,9 @@ func TestNewApp(t *testing.T) {
 		t.Fatal("Expected app to not be nil")
 	}
 
-	if app.storage == nil {
-		t.Fatal("Expected app.storage to not be nil")
+	// The store is opened by Run once the global flags are known
+	if app.storage != nil {
+		t.Fatal("Expected app.storage to be opened by Run, not NewApp")
 	}
 }
 
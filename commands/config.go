@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mstgnz/cli-task-manager/config"
+)
+
+// handleConfig handles the config command
+func (a *App) handleConfig(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Subcommand is required (get, set, list)")
+		return nil
+	}
+
+	parsedArgs := parseArgs(args[1:])
+	positional := positionalArgs(args[1:])
+
+	switch args[0] {
+	case "list":
+		cfg := a.settings()
+		for _, name := range config.Keys() {
+			value, _ := cfg.Get(name)
+			fmt.Printf("%s = %s\n", name, value)
+		}
+		return nil
+	case "get":
+		if len(positional) == 0 {
+			fmt.Println("Error: Config key is required")
+			return nil
+		}
+		value, err := a.settings().Get(positional[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	case "set":
+		if len(positional) < 2 {
+			fmt.Println("Error: Config key and value are required")
+			return nil
+		}
+		// Check the value against the effective configuration, which holds
+		// the contexts of every config file
+		if err := a.settings().Check(positional[0], positional[1]); err != nil {
+			return err
+		}
+		path, err := configFilePath(parsedArgs["local"] == "true")
+		if err != nil {
+			return err
+		}
+		fileCfg, err := config.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fileCfg.Set(positional[0], positional[1]); err != nil {
+			return err
+		}
+		if err := fileCfg.Save(path); err != nil {
+			return err
+		}
+		if a.config != nil {
+			_ = a.config.Set(positional[0], positional[1])
+		}
+		fmt.Printf("Set %s = %s in %s\n", positional[0], positional[1], path)
+		return nil
+	default:
+		fmt.Printf("Unknown config subcommand: %s\n", args[0])
+		return nil
+	}
+}
+
+// configFilePath returns the config file written by `config set`: the nearest
+// local override when local is true, or a new one at the root of the
+// repository, or in the working directory outside of one, and otherwise the
+// user config file
+func configFilePath(local bool) (string, error) {
+	if !local {
+		return config.UserConfigPath()
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	if path := config.FindLocalConfig(cwd); path != "" {
+		return path, nil
+	}
+
+	dir := config.FindRepoRoot(cwd)
+	if dir == "" {
+		dir = cwd
+	}
+	return filepath.Join(dir, config.LocalConfigFile), nil
+}
+
+// updateUserConfig applies change to the user config file and then to the
+// in-memory configuration, so that subsequent commands see it. The in-memory
+// configuration also holds the entries of a local override file, so change
+// must only touch the entries it is about rather than replace whole maps.
+func (a *App) updateUserConfig(change func(cfg *config.Config) error) error {
+	path, err := config.UserConfigPath()
+	if err != nil {
+		return err
+	}
+
+	userCfg, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := change(userCfg); err != nil {
+		return err
+	}
+
+	if err := userCfg.Save(path); err != nil {
+		return err
+	}
+
+	if a.config != nil {
+		return change(a.config)
+	}
+	return nil
+}
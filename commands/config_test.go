@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleConfig(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-command-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	app := &App{
+		storage: storage.NewMockStorage(),
+		config:  config.Default(),
+	}
+
+	// Test listing the configuration
+	if err := app.handleConfig([]string{"list"}); err != nil {
+		t.Errorf("Expected no error when listing config, got %v", err)
+	}
+
+	// Test setting a value
+	if err := app.handleConfig([]string{"set", "default_label", "bug"}); err != nil {
+		t.Fatalf("Expected no error when setting config, got %v", err)
+	}
+
+	saved, err := config.LoadFile(filepath.Join(tempDir, config.AppName, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+
+	if saved.DefaultLabel != "bug" {
+		t.Errorf("Expected saved default label to be 'bug', got %s", saved.DefaultLabel)
+	}
+
+	if app.config.DefaultLabel != "bug" {
+		t.Errorf("Expected effective default label to be 'bug', got %s", app.config.DefaultLabel)
+	}
+
+	// Test getting a value
+	if err := app.handleConfig([]string{"get", "default_label"}); err != nil {
+		t.Errorf("Expected no error when getting config, got %v", err)
+	}
+
+	// Test invalid key and value
+	if err := app.handleConfig([]string{"get", "unknown"}); err == nil {
+		t.Error("Expected error when getting unknown key, got nil")
+	}
+
+	if err := app.handleConfig([]string{"set", "output", "xml"}); err == nil {
+		t.Error("Expected error when setting invalid output, got nil")
+	}
+
+	if err := app.handleConfig([]string{"set", "current_context", "unknown"}); err == nil {
+		t.Error("Expected error when switching to an unknown context, got nil")
+	}
+
+	// Test missing arguments
+	if err := app.handleConfig([]string{}); err != nil {
+		t.Errorf("Expected no error with no subcommand, got %v", err)
+	}
+
+	if err := app.handleConfig([]string{"set", "output"}); err != nil {
+		t.Errorf("Expected no error with missing value, got %v", err)
+	}
+}
+
+func TestHandleAddUsesDefaultLabel(t *testing.T) {
+	cfg := config.Default()
+	cfg.DefaultLabel = "chore"
+
+	app := &App{
+		storage: storage.NewMockStorage(),
+		config:  cfg,
+	}
+
+	if err := app.handleAdd([]string{"Clean up"}); err != nil {
+		t.Fatalf("Expected no error when adding task, got %v", err)
+	}
+
+	task, err := app.storage.GetTaskByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+
+	if task.Label != "chore" {
+		t.Errorf("Expected label to be 'chore', got %s", task.Label)
+	}
+}
+
+func TestHandleConfigLocal(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-local-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "config"))
+
+	repoDir := filepath.Join(tempDir, "repo")
+	nestedDir := filepath.Join(repoDir, "src", "api")
+	if err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git directory: %v", err)
+	}
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(nestedDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	app := &App{config: config.Default()}
+
+	// Test creating the local override at the repository root
+	if err := app.handleConfig([]string{"set", "default_label", "bug", "--local"}); err != nil {
+		t.Fatalf("Expected no error when setting local config, got %v", err)
+	}
+
+	saved, err := config.LoadFile(filepath.Join(repoDir, config.LocalConfigFile))
+	if err != nil {
+		t.Fatalf("Failed to load local config: %v", err)
+	}
+	if saved.DefaultLabel != "bug" {
+		t.Errorf("Expected the local config at the repository root, got %+v", saved)
+	}
+
+	if _, err := os.Stat(filepath.Join(nestedDir, config.LocalConfigFile)); !os.IsNotExist(err) {
+		t.Error("Expected no local config in the working directory")
+	}
+}
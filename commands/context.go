@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+// handleContext handles the context command
+func (a *App) handleContext(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Subcommand is required (create, use, list, delete)")
+		return nil
+	}
+
+	parsedArgs := parseArgs(args[1:])
+	name := parsedArgs["main"]
+
+	switch args[0] {
+	case "list":
+		return a.listContexts()
+	case "create", "use", "delete":
+		if name == "" {
+			fmt.Println("Error: Context name is required")
+			return nil
+		}
+	default:
+		fmt.Printf("Unknown context subcommand: %s\n", args[0])
+		return nil
+	}
+
+	switch args[0] {
+	case "create":
+		// A context of a local override would shadow the new one
+		ctx, err := newContext(a.settings(), name, parsedArgs)
+		if err != nil {
+			return err
+		}
+		// Contexts are always stored in the user config file
+		if err := a.updateUserConfig(func(cfg *config.Config) error {
+			if cfg.Contexts == nil {
+				cfg.Contexts = make(map[string]config.Context)
+			}
+			cfg.Contexts[name] = ctx
+			return nil
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("Context %s created: %s\n", name, ctx.StoragePath)
+	case "use":
+		if _, ok := a.settings().Contexts[name]; !ok && name != config.DefaultContext {
+			return fmt.Errorf("unknown context: %s", name)
+		}
+		current := name
+		if name == config.DefaultContext {
+			current = ""
+		}
+		if err := a.updateUserConfig(func(cfg *config.Config) error {
+			cfg.CurrentContext = current
+			return nil
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("Switched to context %s\n", name)
+	case "delete":
+		var ctx config.Context
+		if err := a.updateUserConfig(func(cfg *config.Config) error {
+			var ok bool
+			if ctx, ok = cfg.Contexts[name]; !ok {
+				return fmt.Errorf("unknown context: %s", name)
+			}
+			delete(cfg.Contexts, name)
+			if cfg.CurrentContext == name {
+				cfg.CurrentContext = ""
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("Context %s deleted (tasks file kept at %s)\n", name, ctx.StoragePath)
+	}
+
+	return nil
+}
+
+// newContext returns a new context named name, failing when cfg already
+// has one of that name
+func newContext(cfg *config.Config, name string, parsedArgs map[string]string) (config.Context, error) {
+	if name == config.DefaultContext || strings.ContainsAny(name, `/\`) {
+		return config.Context{}, fmt.Errorf("invalid context name: %s", name)
+	}
+
+	if _, ok := cfg.Contexts[name]; ok {
+		return config.Context{}, fmt.Errorf("context already exists: %s", name)
+	}
+
+	backend := parsedArgs["backend"]
+	if backend == "" {
+		backend = storage.BackendJSON
+	}
+	if !isBackend(backend) {
+		return config.Context{}, fmt.Errorf("unknown storage backend: %s (available: %s)", backend, strings.Join(storage.Backends(), ", "))
+	}
+
+	storagePath, err := contextStoragePath(name, backend, parsedArgs["path"])
+	if err != nil {
+		return config.Context{}, err
+	}
+
+	return config.Context{StoragePath: storagePath, Backend: backend}, nil
+}
+
+// contextStoragePath returns the absolute store path of a new context,
+// defaulting to a per-context directory in the data directory
+func contextStoragePath(name, backend, path string) (string, error) {
+	if path == "" {
+		dataDir, err := config.DataDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dataDir, "contexts", name, storage.FileName(backend)), nil
+	}
+
+	expanded, err := config.ExpandPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(expanded)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	return absPath, nil
+}
+
+// listContexts prints the configured contexts, marking the current one
+func (a *App) listContexts() error {
+	cfg := a.settings()
+	current := cfg.ActiveContext()
+
+	globalPath, err := cfg.ResolveStoragePath()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Contexts:")
+	printContext(config.DefaultContext, config.Context{StoragePath: globalPath}, current)
+	for _, name := range cfg.ContextNames() {
+		printContext(name, cfg.Contexts[name], current)
+	}
+
+	return nil
+}
+
+// printContext prints a single context line
+func printContext(name string, ctx config.Context, current string) {
+	marker := " "
+	if name == current {
+		marker = "*"
+	}
+
+	backend := ctx.Backend
+	if backend == "" {
+		backend = storage.BackendJSON
+	}
+
+	fmt.Printf("%s %s (%s) %s\n", marker, name, backend, ctx.StoragePath)
+}
+
+// isBackend reports whether name is a supported storage backend
+func isBackend(name string) bool {
+	for _, backend := range storage.Backends() {
+		if backend == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleContext(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "context-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	cfg := config.Default()
+	cfg.StoragePath = filepath.Join(tempDir, "global", "tasks.json")
+	// A context of a local override file, which the user config file lacks
+	localPath := filepath.Join(tempDir, "local", "tasks.json")
+	cfg.Contexts = map[string]config.Context{"local": {StoragePath: localPath}}
+	app := &App{
+		storage: storage.NewMockStorage(),
+		config:  cfg,
+	}
+
+	workPath := filepath.Join(tempDir, "work", "tasks.json")
+
+	// Test creating a context
+	if err := app.handleContext([]string{"create", "work", "--path", workPath}); err != nil {
+		t.Fatalf("Expected no error when creating context, got %v", err)
+	}
+
+	if app.config.Contexts["work"].StoragePath != workPath {
+		t.Errorf("Expected context path %s, got %s", workPath, app.config.Contexts["work"].StoragePath)
+	}
+
+	if app.config.Contexts["local"].StoragePath != localPath {
+		t.Errorf("Expected the local override context to be kept, got %v", app.config.Contexts)
+	}
+
+	// Test creating a duplicate or reserved context
+	if err := app.handleContext([]string{"create", "work"}); err == nil {
+		t.Error("Expected error when creating duplicate context, got nil")
+	}
+
+	if err := app.handleContext([]string{"create", config.DefaultContext}); err == nil {
+		t.Error("Expected error when creating reserved context, got nil")
+	}
+
+	if err := app.handleContext([]string{"create", "oss", "--backend", "xml"}); err == nil {
+		t.Error("Expected error when creating context with unknown backend, got nil")
+	}
+
+	// Test switching contexts
+	if err := app.handleContext([]string{"use", "work"}); err != nil {
+		t.Fatalf("Expected no error when switching context, got %v", err)
+	}
+
+	saved, err := config.LoadFile(filepath.Join(tempDir, config.AppName, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+
+	if saved.CurrentContext != "work" {
+		t.Errorf("Expected saved current context to be 'work', got %s", saved.CurrentContext)
+	}
+
+	if _, ok := saved.Contexts["local"]; ok {
+		t.Error("Expected the local override context not to be saved in the user config file")
+	}
+
+	if err := app.handleContext([]string{"create", "local"}); err == nil {
+		t.Error("Expected error when creating context shadowed by a local override, got nil")
+	}
+
+	if err := app.handleContext([]string{"use", "unknown"}); err == nil {
+		t.Error("Expected error when switching to unknown context, got nil")
+	}
+
+	// Test listing contexts
+	if err := app.handleContext([]string{"list"}); err != nil {
+		t.Errorf("Expected no error when listing contexts, got %v", err)
+	}
+
+	// Test targeting the default context for a single command
+	if err := app.Run([]string{"issue-tracker", "list", "--context", config.DefaultContext}); err != nil {
+		t.Fatalf("Expected no error when listing default context, got %v", err)
+	}
+
+	if app.storePath != cfg.StoragePath || app.context != "" {
+		t.Errorf("Expected global store, got %s (context %q)", app.storePath, app.context)
+	}
+
+	// Test targeting a named context for a single command
+	if err := app.Run([]string{"issue-tracker", "list", "--context", "work"}); err != nil {
+		t.Fatalf("Expected no error when listing work context, got %v", err)
+	}
+
+	if app.storePath != workPath || app.context != "work" {
+		t.Errorf("Expected work store, got %s (context %q)", app.storePath, app.context)
+	}
+
+	if err := app.Run([]string{"issue-tracker", "list", "--context", "unknown"}); err == nil {
+		t.Error("Expected error when targeting unknown context, got nil")
+	}
+
+	// Test deleting the current context
+	if err := app.handleContext([]string{"delete", "work"}); err != nil {
+		t.Fatalf("Expected no error when deleting context, got %v", err)
+	}
+
+	if app.config.ActiveContext() != config.DefaultContext {
+		t.Errorf("Expected default context after delete, got %s", app.config.ActiveContext())
+	}
+
+	if _, ok := app.config.Contexts["local"]; !ok {
+		t.Error("Expected the local override context to survive deleting another context")
+	}
+
+	if err := app.handleContext([]string{"delete", "local"}); err == nil {
+		t.Error("Expected error when deleting context of a local override, got nil")
+	}
+
+	if err := app.handleContext([]string{"delete", "work"}); err == nil {
+		t.Error("Expected error when deleting unknown context, got nil")
+	}
+
+	// Test missing arguments
+	if err := app.handleContext([]string{}); err != nil {
+		t.Errorf("Expected no error with no subcommand, got %v", err)
+	}
+
+	if err := app.handleContext([]string{"use"}); err != nil {
+		t.Errorf("Expected no error with no context name, got %v", err)
+	}
+}
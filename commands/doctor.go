@@ -0,0 +1,246 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+// problem is an inconsistency found in the task store
+type problem struct {
+	task    models.Task
+	message string
+	// repair describes how --fix repairs the problem, empty if it cannot
+	repair string
+}
+
+// diagnosis is the result of checking the tasks of a store
+type diagnosis struct {
+	problems []problem
+	// tasks are the tasks with every repairable problem repaired
+	tasks []models.Task
+}
+
+// diagnose checks tasks for invalid or duplicate IDs, invalid statuses,
+// missing or inconsistent timestamps and references to unknown milestones.
+// A nil milestones map skips the milestone check.
+func diagnose(tasks []models.Task, workflow *models.Workflow, milestones map[string]bool, now time.Time) diagnosis {
+	var result diagnosis
+
+	nextID := 1
+	for _, task := range tasks {
+		if task.ID >= nextID {
+			nextID = task.ID + 1
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, task := range tasks {
+		original := task
+		report := func(message, repair string) {
+			result.problems = append(result.problems, problem{task: original, message: message, repair: repair})
+		}
+
+		switch {
+		case task.ID <= 0:
+			report("non-positive ID", fmt.Sprintf("renumber to %d", nextID))
+			task.ID = nextID
+			nextID++
+		case seen[task.ID]:
+			report("duplicate ID", fmt.Sprintf("renumber to %d", nextID))
+			task.ID = nextID
+			nextID++
+		}
+		seen[task.ID] = true
+
+		if task.Status == "" {
+			task.Status = workflow.InitialStatus()
+			report("missing status", fmt.Sprintf("set to %s", task.Status))
+		} else if !workflow.IsValid(task.Status) {
+			report(fmt.Sprintf("unknown status %q", task.Status), "")
+		}
+
+		if task.CreatedAt.IsZero() {
+			if task.UpdatedAt.IsZero() {
+				task.CreatedAt = now
+				report("created_at is not set", "set to the current time")
+			} else {
+				task.CreatedAt = task.UpdatedAt
+				report("created_at is not set", "set to updated_at")
+			}
+		}
+
+		if task.UpdatedAt.IsZero() {
+			task.UpdatedAt = task.CreatedAt
+			report("updated_at is not set", "set to created_at")
+		} else if task.UpdatedAt.Before(task.CreatedAt) {
+			task.UpdatedAt = task.CreatedAt
+			report("updated_at is before created_at", "set to created_at")
+		}
+
+		// Only the user knows whether the milestone was deleted or renamed
+		if task.Milestone != "" && milestones != nil && !milestones[task.Milestone] {
+			report(fmt.Sprintf("milestone %q does not exist", task.Milestone), "")
+		}
+
+		result.tasks = append(result.tasks, task)
+	}
+
+	return result
+}
+
+// handleDoctor handles the doctor command
+func (a *App) handleDoctor(args []string) error {
+	_, fix := parseArgs(args)["fix"]
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		fmt.Printf("The task store %s cannot be read: %s\n", a.storePath, a.describeReadError(err))
+		if name := a.newestReadableBackup(); name != "" {
+			fmt.Printf("The newest readable backup is %s, restore it with: backup restore %s\n", name, name)
+		}
+		return errors.New("the task store cannot be read")
+	}
+
+	var milestones map[string]bool
+	if a.milestones != nil {
+		list, err := a.milestones.GetMilestones()
+		if err != nil {
+			fmt.Printf("The milestones cannot be read: %v\n", err)
+		} else {
+			milestones = make(map[string]bool)
+			for _, milestone := range list {
+				milestones[milestone.Name] = true
+			}
+		}
+	}
+
+	now := time.Now()
+	result := diagnose(tasks, a.workflow(), milestones, now)
+	if len(result.problems) == 0 {
+		fmt.Printf("No problems found in %d tasks.\n", len(tasks))
+		return nil
+	}
+
+	repairable := 0
+	for _, p := range result.problems {
+		line := fmt.Sprintf("Task %d %q: %s", p.task.ID, p.task.Title, p.message)
+		switch {
+		case p.repair == "":
+			line += " (needs a manual fix)"
+		case fix:
+			line += fmt.Sprintf(" (fixed: %s)", p.repair)
+		default:
+			line += fmt.Sprintf(" (--fix will %s)", p.repair)
+		}
+		if p.repair != "" {
+			repairable++
+		}
+		fmt.Println(line)
+	}
+
+	if !fix || repairable == 0 {
+		fmt.Printf("Found %d problems, %d can be fixed with --fix\n", len(result.problems), repairable)
+		return nil
+	}
+
+	if err := a.repair(result); err != nil {
+		return err
+	}
+
+	fmt.Printf("Found %d problems, fixed %d\n", len(result.problems), repairable)
+	return nil
+}
+
+// repair saves the repaired tasks
+func (a *App) repair(result diagnosis) error {
+	replacer, ok := a.storage.(storage.Replacer)
+	if !ok {
+		return errors.New("the task store cannot be repaired")
+	}
+
+	if err := replacer.ReplaceTasks(result.tasks); err != nil {
+		return fmt.Errorf("failed to save repaired tasks: %w", err)
+	}
+
+	return nil
+}
+
+// describeReadError explains why the store could not be read, adding the
+// line and column of JSON syntax errors
+func (a *App) describeReadError(err error) string {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err.Error()
+	}
+
+	data, readErr := os.ReadFile(a.storePath)
+	if readErr != nil || storage.IsEncrypted(data) {
+		return err.Error()
+	}
+
+	offset := int(syntaxErr.Offset)
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(data[:offset], '\n')
+
+	return fmt.Sprintf("%s at line %d, column %d", syntaxErr, line, column)
+}
+
+// newestReadableBackup returns the name of the newest backup that can be
+// read with the store's backend, or an empty string if there is none
+func (a *App) newestReadableBackup() string {
+	if a.backups == nil {
+		return ""
+	}
+
+	backups, err := a.backups.List()
+	if err != nil {
+		return ""
+	}
+
+	// Load the key of each kind once rather than asking for a passphrase
+	// for every backup
+	type loaded struct {
+		cipher *storage.Cipher
+		err    error
+	}
+	ciphers := make(map[bool]loaded)
+
+	for _, backup := range backups {
+		var c *storage.Cipher
+		if encrypted, _ := storage.IsEncryptedFile(backup.Path); encrypted {
+			passphrase, err := storage.IsPassphraseEncryptedFile(backup.Path)
+			if err != nil {
+				continue
+			}
+			key, ok := ciphers[passphrase]
+			if !ok {
+				key.cipher, key.err = a.loadCipher(backup.Path, a.storePath)
+				ciphers[passphrase] = key
+			}
+			if key.err != nil {
+				continue
+			}
+			c = key.cipher
+		}
+
+		store, err := storage.OpenEncrypted(a.storeBackend, backup.Path, a.workflow(), c)
+		if err != nil {
+			continue
+		}
+		if _, err := store.GetTasks(); err == nil {
+			return backup.Name
+		}
+	}
+
+	return ""
+}
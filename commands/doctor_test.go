@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestDiagnose(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{ID: 1, Title: "Healthy", Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created},
+		{ID: 1, Title: "Duplicate", Status: models.StatusDone, CreatedAt: created, UpdatedAt: created},
+		{ID: 0, Title: "No ID", Status: "", CreatedAt: created, UpdatedAt: created.Add(-time.Hour)},
+		{ID: 5, Title: "Unknown", Status: "blocked", UpdatedAt: created, Milestone: "v2"},
+	}
+
+	result := diagnose(tasks, models.DefaultWorkflow(), map[string]bool{"v1": true}, now)
+
+	if len(result.problems) != 7 {
+		t.Fatalf("Expected 7 problems, got %d: %v", len(result.problems), result.problems)
+	}
+
+	ids := []int{result.tasks[0].ID, result.tasks[1].ID, result.tasks[2].ID, result.tasks[3].ID}
+	if ids[0] != 1 || ids[1] != 6 || ids[2] != 7 || ids[3] != 5 {
+		t.Errorf("Expected IDs 1, 6, 7 and 5 after renumbering, got %v", ids)
+	}
+
+	if result.tasks[2].Status != models.StatusTodo {
+		t.Errorf("Expected missing status to become %s, got %s", models.StatusTodo, result.tasks[2].Status)
+	}
+
+	if !result.tasks[2].UpdatedAt.Equal(created) {
+		t.Errorf("Expected updated_at to be raised to created_at, got %s", result.tasks[2].UpdatedAt)
+	}
+
+	if !result.tasks[3].CreatedAt.Equal(created) {
+		t.Errorf("Expected missing created_at to be set from updated_at, got %s", result.tasks[3].CreatedAt)
+	}
+
+	if result.tasks[3].Status != "blocked" {
+		t.Errorf("Expected unknown status to be left for a manual fix, got %s", result.tasks[3].Status)
+	}
+
+	if last := result.problems[len(result.problems)-1]; last.message != `milestone "v2" does not exist` || last.repair != "" {
+		t.Errorf("Expected missing milestone v2 to need a manual fix, got %+v", last)
+	}
+
+	// Test skipping the milestone check without milestones
+	if result := diagnose(tasks[3:], models.DefaultWorkflow(), nil, now); len(result.problems) != 2 {
+		t.Errorf("Expected no milestone problem without milestone storage, got %v", result.problems)
+	}
+}
+
+func TestHandleDoctor(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "doctor-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	data := `[
+  {"id": 1, "title": "First", "status": "to-do", "label": "task", "created_at": "2024-03-01T09:00:00Z", "updated_at": "2024-03-01T09:00:00Z"},
+  {"id": 1, "title": "Second", "status": "to-do", "label": "task", "created_at": "2024-03-01T09:00:00Z", "updated_at": "2024-03-01T09:00:00Z", "milestone": "v1"}
+]`
+	if err := os.WriteFile(filePath, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write tasks file: %v", err)
+	}
+
+	jsonStorage, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	app := &App{
+		storage:    jsonStorage,
+		milestones: storage.NewMockMilestoneStorage(),
+		storePath:  filePath,
+	}
+
+	// Test reporting without changing the store
+	if err := app.handleDoctor([]string{}); err != nil {
+		t.Fatalf("Expected no error when checking, got %v", err)
+	}
+
+	if _, err := app.storage.GetTaskByID(2); err == nil {
+		t.Error("Expected the store to be unchanged without --fix")
+	}
+
+	// Test repairing the store
+	if err := app.handleDoctor([]string{"--fix"}); err != nil {
+		t.Fatalf("Expected no error when fixing, got %v", err)
+	}
+
+	task, err := app.storage.GetTaskByID(2)
+	if err != nil {
+		t.Fatalf("Expected duplicate task to be renumbered, got %v", err)
+	}
+
+	if task.Title != "Second" {
+		t.Errorf("Expected task 2 to be 'Second', got %s", task.Title)
+	}
+
+	if _, err := app.milestones.GetMilestone("v1"); err == nil {
+		t.Error("Expected the missing milestone not to be made up")
+	}
+	if task.Milestone != "v1" {
+		t.Errorf("Expected the milestone reference to be left for a manual fix, got %q", task.Milestone)
+	}
+
+	// Test reporting a store that cannot be read
+	if err := os.WriteFile(filePath, []byte("[{\"id\": 1,,}]"), 0644); err != nil {
+		t.Fatalf("Failed to write tasks file: %v", err)
+	}
+
+	if err := app.handleDoctor([]string{}); err == nil {
+		t.Error("Expected error when the store cannot be read, got nil")
+	}
+
+	_, err = app.storage.GetTasks()
+	if message := app.describeReadError(err); message == err.Error() {
+		t.Errorf("Expected the syntax error position to be reported, got %s", message)
+	}
+}
+
+func TestNewestReadableBackup(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "doctor-backup-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(passphraseEnv, "correct horse")
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	jsonStorage, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+	jsonStorage.AddTask(models.Task{Title: "Keep", Status: models.StatusTodo})
+
+	backups := storage.NewBackups(filePath, filepath.Join(tempDir, "backups"), storage.BackupPolicy{Count: 5})
+	readable, err := backups.Create()
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	c, err := storage.NewPassphraseCipher("correct horse")
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	if err := storage.EncryptFile(readable.Path, c); err != nil {
+		t.Fatalf("Failed to encrypt backup: %v", err)
+	}
+
+	// A newer backup of a broken store is skipped
+	if err := os.WriteFile(filePath, []byte("[{\"id\": 1,,}]"), 0644); err != nil {
+		t.Fatalf("Failed to write tasks file: %v", err)
+	}
+	if _, err := backups.Create(); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	app := &App{
+		storage:      jsonStorage,
+		backups:      backups,
+		storePath:    filePath,
+		storeBackend: storage.BackendJSON,
+	}
+
+	if name := app.newestReadableBackup(); name != readable.Name {
+		t.Errorf("Expected the encrypted backup %s to be named, got %q", readable.Name, name)
+	}
+}
@@ -0,0 +1,365 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+	"golang.org/x/term"
+)
+
+// Environment variables providing the key of encrypted task stores
+const (
+	encryptionKeyEnv = config.EnvPrefix + "ENCRYPTION_KEY"
+	passphraseEnv    = config.EnvPrefix + "PASSPHRASE"
+)
+
+// openBackend opens the storage of a backend at path, decrypting it when
+// the file is encrypted with the key of the store at storePath, which differs
+// from path for backups. It returns the cipher used, or nil.
+func (a *App) openBackend(backend, path, storePath string) (storage.Storage, *storage.Cipher, error) {
+	encrypted, err := storage.IsEncryptedFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	var c *storage.Cipher
+	if encrypted {
+		if c, err = a.loadCipher(path, storePath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	store, err := storage.OpenEncrypted(backend, path, a.workflow(), c)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	return store, c, nil
+}
+
+// loadCipher builds the cipher of the encrypted file at path. The key of a
+// file encrypted with a key is taken from the key file of the store at
+// storePath, the environment or the default key file, and the passphrase of a
+// file encrypted with a passphrase from the environment or the terminal, so
+// that each file gets a key of its own kind.
+func (a *App) loadCipher(path, storePath string) (*storage.Cipher, error) {
+	passphrase, err := storage.IsPassphraseEncryptedFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if passphrase {
+		if value := os.Getenv(passphraseEnv); value != "" {
+			return storage.NewPassphraseCipher(value)
+		}
+		return readPassphrase(path, false)
+	}
+
+	// The key file of the store comes first, as the environment may hold
+	// the key of another store
+	if keyFile := a.settings().KeyFiles[storePath]; keyFile != "" {
+		return keyFileCipher(keyFile)
+	}
+
+	if c, err := envKeyCipher(); c != nil || err != nil {
+		return c, err
+	}
+
+	if keyFile := a.settings().EncryptionKeyFile; keyFile != "" {
+		keyPath, err := config.ExpandPath(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return keyFileCipher(keyPath)
+	}
+
+	return nil, fmt.Errorf("%s is encrypted with a key: set %s or encryption_key_file", path, encryptionKeyEnv)
+}
+
+// newCipher builds the cipher encrypting a store from, in order, the key in
+// the environment, the passphrase in the environment and finally a
+// passphrase read twice from the terminal
+func (a *App) newCipher() (*storage.Cipher, error) {
+	if c, err := envKeyCipher(); c != nil || err != nil {
+		return c, err
+	}
+
+	if value := os.Getenv(passphraseEnv); value != "" {
+		return storage.NewPassphraseCipher(value)
+	}
+
+	return readPassphrase(a.storePath, true)
+}
+
+// envKeyCipher creates a cipher from the key in the environment, or returns
+// nil when none is set
+func envKeyCipher() (*storage.Cipher, error) {
+	value := os.Getenv(encryptionKeyEnv)
+	if value == "" {
+		return nil, nil
+	}
+
+	key, err := storage.ParseKey(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", encryptionKeyEnv, err)
+	}
+	return storage.NewKeyCipher(key)
+}
+
+// keyFileCipher creates a cipher from the key stored in a file
+func keyFileCipher(path string) (*storage.Cipher, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := storage.ParseKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+
+	return storage.NewKeyCipher(key)
+}
+
+// readPassphrase asks for the passphrase of the file at path on the terminal
+// without echoing it
+func readPassphrase(path string, confirm bool) (*storage.Cipher, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("%s is encrypted with a passphrase: set %s", path, passphraseEnv)
+	}
+
+	fmt.Fprintf(os.Stderr, "Passphrase for %s: ", path)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		repeated, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if string(repeated) != string(passphrase) {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+
+	return storage.NewPassphraseCipher(string(passphrase))
+}
+
+// handleEncrypt handles the encrypt command
+func (a *App) handleEncrypt(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	if a.storeBackend == storage.BackendMarkdown {
+		return errors.New("the markdown backend keeps task titles in file names and cannot be encrypted")
+	}
+	if a.cipher != nil {
+		return errors.New("the task store is already encrypted")
+	}
+
+	// Make sure the store is readable before encrypting it
+	if _, err := a.storage.GetTasks(); err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var c *storage.Cipher
+	var err error
+	if keyFile, ok := parsedArgs["key-file"]; ok {
+		c, err = a.useKeyFile(keyFile)
+	} else {
+		c, err = a.newCipher()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := storage.EncryptFile(a.storePath, c); err != nil {
+		return fmt.Errorf("failed to encrypt task store: %w", err)
+	}
+	if err := a.reopenStore(c); err != nil {
+		return err
+	}
+
+	fmt.Printf("Encrypted task store %s\n", a.storePath)
+
+	// Backups taken before would otherwise keep the tasks readable
+	count, err := a.encryptBackups(c)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		fmt.Printf("Encrypted %d backups in %s\n", count, a.backups.Dir())
+	}
+	return nil
+}
+
+// handleDecrypt handles the decrypt command
+func (a *App) handleDecrypt(args []string) error {
+	if a.cipher == nil {
+		return errors.New("the task store is not encrypted")
+	}
+
+	// Decrypt the backups first, so that the key is only forgotten once no
+	// file needs it anymore
+	count, err := a.decryptBackups(a.cipher)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.DecryptFile(a.storePath, a.cipher); err != nil {
+		return fmt.Errorf("failed to decrypt task store: %w", err)
+	}
+	if encrypted, _ := storage.IsEncryptedFile(milestonesPath(a.storePath)); encrypted {
+		if err := storage.DecryptFile(milestonesPath(a.storePath), a.cipher); err != nil {
+			return fmt.Errorf("failed to decrypt milestones: %w", err)
+		}
+	}
+	if err := a.recordKeyFile(a.storePath, ""); err != nil {
+		return err
+	}
+	if err := a.reopenStore(nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Decrypted task store %s\n", a.storePath)
+	if count > 0 {
+		fmt.Printf("Decrypted %d backups in %s\n", count, a.backups.Dir())
+	}
+	return nil
+}
+
+// useKeyFile returns the cipher of a key file, generating a new key if the
+// file does not exist, and records the file as the key of the task store
+func (a *App) useKeyFile(keyFile string) (*storage.Cipher, error) {
+	path, err := config.ExpandPath(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		key, err := storage.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(key+"\n"), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write key file: %w", err)
+		}
+		fmt.Printf("Generated a new key in %s, keep a copy: the tasks cannot be decrypted without it\n", path)
+	}
+
+	c, err := keyFileCipher(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.recordKeyFile(a.storePath, path); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// recordKeyFile records the key file of the task store at storePath in the
+// user config file, or forgets it when keyFile is empty
+func (a *App) recordKeyFile(storePath, keyFile string) error {
+	if keyFile == "" && a.settings().KeyFiles[storePath] == "" {
+		return nil
+	}
+
+	return a.updateUserConfig(func(cfg *config.Config) error {
+		if keyFile == "" {
+			delete(cfg.KeyFiles, storePath)
+			return nil
+		}
+		if cfg.KeyFiles == nil {
+			cfg.KeyFiles = make(map[string]string)
+		}
+		cfg.KeyFiles[storePath] = keyFile
+		return nil
+	})
+}
+
+// reopenStore reopens the task store and its milestones after their files
+// were encrypted with c, or decrypted when c is nil
+func (a *App) reopenStore(c *storage.Cipher) error {
+	store, err := storage.OpenEncrypted(a.storeBackend, a.storePath, a.workflow(), c)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	milestones, err := openMilestones(a.storePath, c)
+	if err != nil {
+		return err
+	}
+
+	if a.backups != nil {
+		a.storage = storage.NewBackupStorage(store, a.backups)
+	} else {
+		a.storage = store
+	}
+	a.milestones = milestones
+	a.cipher = c
+	return nil
+}
+
+// encryptBackups encrypts the backups taken before the task store was
+// encrypted and returns how many it encrypted
+func (a *App) encryptBackups(c *storage.Cipher) (int, error) {
+	return a.convertBackups(c, true)
+}
+
+// decryptBackups decrypts the backups of the task store, so that restoring
+// one after decrypt leaves a readable store, and returns how many it decrypted
+func (a *App) decryptBackups(c *storage.Cipher) (int, error) {
+	return a.convertBackups(c, false)
+}
+
+// convertBackups encrypts or decrypts with c the files of every backup not
+// yet in the wanted state, including the copies of the milestones, and
+// returns how many backups it changed
+func (a *App) convertBackups(c *storage.Cipher, encrypt bool) (int, error) {
+	if a.backups == nil {
+		return 0, nil
+	}
+
+	backups, err := a.backups.List()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, backup := range backups {
+		changed := false
+		for _, path := range append([]string{backup.Path}, backup.Related...) {
+			encrypted, err := storage.IsEncryptedFile(path)
+			if err != nil {
+				return count, fmt.Errorf("failed to read backup %s: %w", backup.Name, err)
+			}
+			if encrypted == encrypt {
+				continue
+			}
+
+			if encrypt {
+				err = storage.EncryptFile(path, c)
+			} else {
+				err = storage.DecryptFile(path, c)
+			}
+			if err != nil {
+				return count, fmt.Errorf("failed to convert backup %s: %w", backup.Name, err)
+			}
+			changed = true
+		}
+		if changed {
+			count++
+		}
+	}
+
+	return count, nil
+}
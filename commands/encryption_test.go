@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleEncrypt(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "encrypt-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(passphraseEnv, "")
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	jsonStorage, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+	jsonStorage.AddTask(models.Task{Title: "Call ACME", Status: models.StatusTodo, Label: "task"})
+
+	milestones, err := openMilestones(filePath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open milestones: %v", err)
+	}
+	milestones.SaveMilestone(models.Milestone{Name: "ACME rollout"})
+
+	backups := storage.NewBackups(filePath, filepath.Join(tempDir, "backups"), storage.BackupPolicy{Count: 5}, milestonesPath(filePath))
+	backup, err := backups.Create()
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	app := &App{
+		storage:      jsonStorage,
+		milestones:   milestones,
+		backups:      backups,
+		config:       config.Default(),
+		storePath:    filePath,
+		storeBackend: storage.BackendJSON,
+	}
+
+	// Test decrypting a store that is not encrypted
+	if err := app.handleDecrypt([]string{}); err == nil {
+		t.Error("Expected error when decrypting a plain store, got nil")
+	}
+
+	// Test encrypting with a newly generated key file
+	keyFile := filepath.Join(tempDir, "task.key")
+	if err := app.handleEncrypt([]string{"--key-file", keyFile}); err != nil {
+		t.Fatalf("Expected no error when encrypting, got %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read tasks file: %v", err)
+	}
+
+	if !storage.IsEncrypted(data) || bytes.Contains(data, []byte("ACME")) {
+		t.Error("Expected the tasks file to be encrypted")
+	}
+
+	// Test the milestones and the earlier backups being encrypted too
+	for _, path := range append([]string{milestonesPath(filePath), backup.Path}, backup.Related...) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if !storage.IsEncrypted(data) || bytes.Contains(data, []byte("ACME")) {
+			t.Errorf("Expected %s to be encrypted", path)
+		}
+	}
+
+	if _, err := app.milestones.GetMilestone("ACME rollout"); err != nil {
+		t.Errorf("Expected milestone to be readable after encrypting, got %v", err)
+	}
+
+	saved, err := config.LoadFile(filepath.Join(tempDir, config.AppName, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+
+	if saved.KeyFiles[filePath] != keyFile || saved.EncryptionKeyFile != "" {
+		t.Errorf("Expected key file %s saved for the store only, got %v", keyFile, saved.KeyFiles)
+	}
+
+	if err := app.handleEncrypt([]string{}); err == nil {
+		t.Error("Expected error when encrypting an encrypted store, got nil")
+	}
+
+	// Test reopening the store with the configured key file
+	reopened := &App{config: app.config}
+	store, c, err := reopened.openBackend(storage.BackendJSON, filePath, filePath)
+	if err != nil {
+		t.Fatalf("Expected no error when opening encrypted store, got %v", err)
+	}
+
+	if c == nil {
+		t.Error("Expected the encrypted store to be opened with a cipher")
+	}
+
+	task, err := store.GetTaskByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get task from encrypted store: %v", err)
+	}
+
+	if task.Title != "Call ACME" {
+		t.Errorf("Expected title 'Call ACME', got %s", task.Title)
+	}
+
+	// Test decrypting the store
+	if err := app.handleDecrypt([]string{}); err != nil {
+		t.Fatalf("Expected no error when decrypting, got %v", err)
+	}
+
+	for _, path := range []string{filePath, milestonesPath(filePath)} {
+		if encrypted, _ := storage.IsEncryptedFile(path); encrypted {
+			t.Errorf("Expected %s to be decrypted", path)
+		}
+	}
+
+	if _, err := app.storage.GetTaskByID(1); err != nil {
+		t.Errorf("Expected task to be readable after decrypting, got %v", err)
+	}
+
+	if app.config.KeyFiles[filePath] != "" {
+		t.Error("Expected the key file to be forgotten after decrypting")
+	}
+
+	// Test restoring a backup taken before decrypting
+	app.storage.AddTask(models.Task{Title: "Call Globex", Status: models.StatusTodo, Label: "task"})
+	if err := app.handleBackup([]string{"restore", backup.Name}); err != nil {
+		t.Fatalf("Expected no error when restoring backup, got %v", err)
+	}
+
+	reopened = &App{config: app.config}
+	store, _, err = reopened.openBackend(storage.BackendJSON, filePath, filePath)
+	if err != nil {
+		t.Fatalf("Expected the restored store to be readable, got %v", err)
+	}
+	if tasks, _ := store.GetTasks(); len(tasks) != 1 {
+		t.Errorf("Expected 1 task in the restored store, got %d", len(tasks))
+	}
+	if _, err := app.milestones.GetMilestone("ACME rollout"); err != nil {
+		t.Errorf("Expected the restored milestones to be readable, got %v", err)
+	}
+}
+
+func TestLoadCipher(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "load-cipher-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(passphraseEnv, "correct horse")
+
+	key, err := storage.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyFile := filepath.Join(tempDir, "task.key")
+	if err := os.WriteFile(keyFile, []byte(key), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	// Encrypt one store with the passphrase and another with the key file
+	passphraseCipher, _ := storage.NewPassphraseCipher("correct horse")
+	keyCipher, err := keyFileCipher(keyFile)
+	if err != nil {
+		t.Fatalf("Failed to read key file: %v", err)
+	}
+
+	paths := map[string]*storage.Cipher{
+		filepath.Join(tempDir, "tasks.json"): passphraseCipher,
+		filepath.Join(tempDir, "work.json"):  keyCipher,
+	}
+	for path, c := range paths {
+		if err := os.WriteFile(path, []byte("[]"), 0644); err != nil {
+			t.Fatalf("Failed to write store: %v", err)
+		}
+		if err := storage.EncryptFile(path, c); err != nil {
+			t.Fatalf("Failed to encrypt store: %v", err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.KeyFiles = map[string]string{filepath.Join(tempDir, "work.json"): keyFile}
+	app := &App{config: cfg}
+
+	// Test each store being opened with a key of its own kind
+	for path := range paths {
+		store, _, err := app.openBackend(storage.BackendJSON, path, path)
+		if err != nil {
+			t.Fatalf("Expected no error when opening %s, got %v", path, err)
+		}
+		if _, err := store.GetTasks(); err != nil {
+			t.Errorf("Expected %s to be readable, got %v", path, err)
+		}
+	}
+
+	// Test the store's key file taking precedence over the environment
+	t.Setenv(encryptionKeyEnv, "not a key")
+	workPath := filepath.Join(tempDir, "work.json")
+	if _, err := app.loadCipher(workPath, workPath); err != nil {
+		t.Errorf("Expected the key file of the store to be used, got %v", err)
+	}
+
+	if _, err := (&App{config: config.Default()}).loadCipher(workPath, workPath); err == nil {
+		t.Error("Expected error for an invalid key in the environment, got nil")
+	}
+}
+
+func TestRunWithEncryptedStore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "encrypted-run-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(passphraseEnv, "")
+
+	globalPath := filepath.Join(tempDir, "tasks.json")
+	if _, err := storage.NewJSONStorage(globalPath); err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	c, err := storage.NewPassphraseCipher("correct horse")
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	if err := storage.EncryptFile(globalPath, c); err != nil {
+		t.Fatalf("Failed to encrypt store: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.StoragePath = globalPath
+	cfg.Contexts = map[string]config.Context{"work": {StoragePath: filepath.Join(tempDir, "work.json")}}
+	app := &App{config: cfg}
+
+	// Test commands without a store not asking for the key
+	for _, command := range []string{"help", "config", "statuses"} {
+		if err := app.Run([]string{"issue-tracker", command, "--global"}); err != nil {
+			t.Errorf("Expected no error for %s, got %v", command, err)
+		}
+	}
+
+	if app.storage != nil {
+		t.Error("Expected no store to be opened")
+	}
+
+	// Test a plain context being usable next to an encrypted global store
+	if err := app.Run([]string{"issue-tracker", "add", "Call ACME", "--context", "work"}); err != nil {
+		t.Errorf("Expected no error when adding to a plain context, got %v", err)
+	}
+
+	if err := app.Run([]string{"issue-tracker", "list", "--global"}); err == nil {
+		t.Error("Expected error when opening the encrypted store without a key, got nil")
+	}
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/formats"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleExport handles the export command
+func (a *App) handleExport(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	format, err := a.format(args, parsedArgs)
+	if format == nil {
+		return err
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	path, ok := parsedArgs["file"]
+	if !ok || path == "-" {
+		return format.Encode(os.Stdout, tasks)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	if err := format.Encode(file, tasks); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d tasks to %s\n", len(tasks), path)
+	return nil
+}
+
+// handleImport handles the import command
+func (a *App) handleImport(args []string) error {
+	parsedArgs := parseArgs(args)
+	positional := positionalArgs(args)
+
+	if len(positional) == 0 {
+		fmt.Println("Error: File to import is required (\"-\" reads standard input)")
+		return nil
+	}
+
+	format, err := a.format(args, parsedArgs)
+	if format == nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if path := positional[0]; path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	tasks, err := format.Decode(r)
+	var rowErrors formats.RowErrors
+	if err != nil && !errors.As(err, &rowErrors) {
+		return fmt.Errorf("failed to import tasks: %w", err)
+	}
+
+	// Keyed formats update the tasks imported earlier from the same source
+	existing := make(map[string]models.Task)
+	keyField := ""
+	if keyed, ok := format.(formats.Keyed); ok {
+		keyField = keyed.KeyField()
+		current, err := a.storage.GetTasks()
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+		for _, task := range current {
+			if key := task.Field(keyField); key != "" {
+				existing[key] = task
+			}
+		}
+	}
+
+	dryRun := parsedArgs["dry-run"] == "true"
+	added, updated := 0, 0
+	for _, task := range tasks {
+		a.prepareImportedTask(&task)
+
+		if current, ok := existing[task.Field(keyField)]; keyField != "" && ok {
+			mergeImportedTask(&current, task, a.workflow())
+			updated++
+			if dryRun {
+				fmt.Printf("  update %s\n", current)
+				continue
+			}
+			if err := a.storage.UpdateTask(current); err != nil {
+				return fmt.Errorf("failed to update task: %w", err)
+			}
+			continue
+		}
+
+		added++
+		if dryRun {
+			fmt.Printf("  add    [%s] %s [Status: %s]\n", task.Label, task.Title, task.Status)
+			continue
+		}
+		if _, err := a.storage.AddTask(task); err != nil {
+			return fmt.Errorf("failed to add task: %w", err)
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		fmt.Printf("%d rows failed validation:\n", len(rowErrors))
+		for _, rowErr := range rowErrors {
+			fmt.Printf("  %s\n", rowErr)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("Would import %d tasks: %d added, %d updated (dry run, nothing saved)\n", len(tasks), added, updated)
+		return nil
+	}
+
+	fmt.Printf("Imported %d tasks: %d added, %d updated\n", len(tasks), added, updated)
+	return nil
+}
+
+// mergeImportedTask updates an existing task with a newer import of it,
+// keeping its ID, time entries and status history
+func mergeImportedTask(task *models.Task, imported models.Task, workflow *models.Workflow) {
+	task.Title = imported.Title
+	task.Description = imported.Description
+	task.Label = imported.Label
+	if imported.Milestone != "" {
+		task.Milestone = imported.Milestone
+	}
+	if imported.Due != nil {
+		task.Due = imported.Due
+	}
+	for name, value := range imported.Fields {
+		task.SetField(name, value)
+	}
+
+	changed := imported.UpdatedAt
+	if completed, ok := imported.CompletedAt(workflow); ok {
+		changed = completed
+	}
+	task.SetStatus(imported.Status, changed)
+
+	if imported.UpdatedAt.After(task.UpdatedAt) {
+		task.UpdatedAt = imported.UpdatedAt
+	}
+}
+
+// format returns the format selected with --format. A nil format with a nil
+// error means the flag was missing and the error has been printed.
+func (a *App) format(args []string, parsedArgs map[string]string) (formats.Format, error) {
+	name, ok := parsedArgs["format"]
+	if !ok {
+		fmt.Printf("Error: --format is required (%s)\n", strings.Join(formats.Names(), ", "))
+		return nil, nil
+	}
+
+	columns := make(map[string]string)
+	for _, mapping := range flagValues(args, "map") {
+		from, to, ok := strings.Cut(mapping, "=")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid column mapping %q, expected <column>=<task column>", mapping)
+		}
+		columns[from] = to
+	}
+
+	return formats.New(name, formats.Options{
+		Workflow: a.workflow(),
+		Fields:   a.settings().Fields,
+		Columns:  columns,
+		GroupBy:  parsedArgs["group"],
+	})
+}
+
+// prepareImportedTask fills in the default label and drops custom field
+// values that do not match their declared type
+func (a *App) prepareImportedTask(task *models.Task) {
+	if task.Label == "" {
+		task.Label = a.settings().DefaultLabel
+	}
+
+	for name, value := range task.Fields {
+		def, ok := a.settings().Fields[name]
+		if !ok {
+			continue
+		}
+		normalized, err := def.Normalize(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: dropping field %s of %q: %v\n", name, task.Title, err)
+		}
+		task.SetField(name, normalized)
+	}
+}
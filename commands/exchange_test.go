@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleExportImport(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "exchange-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	source := &App{storage: storage.NewMockStorage()}
+	if err := source.handleAdd([]string{"First", "--label", "bug"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if err := source.handleAdd([]string{"Second"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if err := source.handleUpdate([]string{"2", "--status", "done"}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	path := filepath.Join(tempDir, "tasks.ics")
+	if err := source.handleExport([]string{"--format", "ics", "--file", path}); err != nil {
+		t.Fatalf("Expected no error for export, got %v", err)
+	}
+
+	target := &App{storage: storage.NewMockStorage()}
+	if err := target.handleImport([]string{path, "--format", "ics"}); err != nil {
+		t.Fatalf("Expected no error for import, got %v", err)
+	}
+
+	tasks, _ := target.storage.GetTasks()
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 imported tasks, got %d", len(tasks))
+	}
+	if tasks[0].Title != "First" || tasks[0].Label != "bug" {
+		t.Errorf("Expected first task to keep its label, got %+v", tasks[0])
+	}
+	if tasks[1].Status != "done" {
+		t.Errorf("Expected second task to be done, got %s", tasks[1].Status)
+	}
+
+	if err := target.handleExport([]string{"--format", "xml"}); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+	if err := target.handleImport([]string{filepath.Join(tempDir, "missing.ics"), "--format", "ics"}); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
+
+func TestHandleImportCSVDryRun(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "exchange-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "tasks.csv")
+	if err := os.WriteFile(path, []byte("Summary,State\nFirst,to-do\nSecond,blocked\n"), 0644); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	app := &App{storage: storage.NewMockStorage()}
+
+	args := []string{path, "--format", "csv", "--map", "Summary=title", "--map", "State=status"}
+	if err := app.handleImport(append(args, "--dry-run")); err != nil {
+		t.Fatalf("Expected no error for dry run, got %v", err)
+	}
+
+	tasks, _ := app.storage.GetTasks()
+	if len(tasks) != 0 {
+		t.Fatalf("Expected dry run to save nothing, got %d tasks", len(tasks))
+	}
+
+	if err := app.handleImport(args); err != nil {
+		t.Fatalf("Expected no error for import, got %v", err)
+	}
+
+	tasks, _ = app.storage.GetTasks()
+	if len(tasks) != 1 || tasks[0].Title != "First" || tasks[0].Label != "task" {
+		t.Errorf("Expected only the valid row with the default label, got %v", tasks)
+	}
+
+	if err := app.handleImport([]string{path, "--format", "csv", "--map", "Summary"}); err == nil {
+		t.Error("Expected error for invalid mapping, got nil")
+	}
+}
+
+func TestHandleImportGitHubUpdates(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "exchange-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "issues.json")
+	write := func(state string) {
+		data := `[{"number": 7, "title": "Login broken", "state": "` + state + `", "labels": [{"name": "bug"}]}]`
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write issues: %v", err)
+		}
+	}
+
+	app := &App{storage: storage.NewMockStorage()}
+
+	write("open")
+	if err := app.handleImport([]string{path, "--format", "github"}); err != nil {
+		t.Fatalf("Expected no error for import, got %v", err)
+	}
+	if err := app.handleLog([]string{"1", "1h"}); err != nil {
+		t.Fatalf("Failed to log time: %v", err)
+	}
+
+	write("closed")
+	if err := app.handleImport([]string{path, "--format", "github"}); err != nil {
+		t.Fatalf("Expected no error for re-import, got %v", err)
+	}
+
+	tasks, _ := app.storage.GetTasks()
+	if len(tasks) != 1 {
+		t.Fatalf("Expected the re-import to update the task, got %d tasks", len(tasks))
+	}
+	if tasks[0].Status != "done" || len(tasks[0].TimeEntries) != 1 || len(tasks[0].History) != 1 {
+		t.Errorf("Expected the task to be closed keeping its time entries, got %+v", tasks[0])
+	}
+
+	if err := app.handleExport([]string{"--format", "github"}); err == nil {
+		t.Error("Expected error exporting to github, got nil")
+	}
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// applyFieldAssignments validates key=value assignments against the declared
+// custom fields and stores them on the task. An empty value unsets the field.
+func (a *App) applyFieldAssignments(task *models.Task, assignments []string) error {
+	for _, assignment := range assignments {
+		name, value, err := a.parseFieldAssignment(assignment)
+		if err != nil {
+			return err
+		}
+		task.SetField(name, value)
+	}
+	return nil
+}
+
+// parseFieldAssignment splits and validates a key=value assignment
+func (a *App) parseFieldAssignment(assignment string) (string, string, error) {
+	return a.parseFieldMatch(assignment, nil)
+}
+
+// parseFieldMatch splits a key=value filter. Besides the declared fields it
+// accepts any field already set on one of tasks, such as the metadata kept
+// by imports, whose values are matched as given.
+func (a *App) parseFieldMatch(assignment string, tasks []models.Task) (string, string, error) {
+	name, value, ok := strings.Cut(assignment, "=")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid field assignment %q, expected key=value", assignment)
+	}
+
+	def, ok := a.settings().Fields[name]
+	if !ok {
+		if hasField(tasks, name) {
+			return name, value, nil
+		}
+		return "", "", fmt.Errorf("unknown field: %s", name)
+	}
+
+	if value == "" {
+		return name, "", nil
+	}
+
+	normalized, err := def.Normalize(value)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid value for field %s: %w", name, err)
+	}
+
+	return name, normalized, nil
+}
+
+// taskLess compares two tasks on a sort key
+type taskLess func(a, b models.Task) bool
+
+// hasField reports whether a field is set on any of tasks
+func hasField(tasks []models.Task, name string) bool {
+	for _, task := range tasks {
+		if _, ok := task.Fields[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTasks sorts tasks by a built-in key or a custom field name
+func (a *App) sortTasks(tasks []models.Task, key string, reverse bool) error {
+	less, err := a.sortFunc(key, tasks)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if reverse {
+			return less(tasks[j], tasks[i])
+		}
+		return less(tasks[i], tasks[j])
+	})
+
+	return nil
+}
+
+// sortFunc returns the comparison for a sort key. Undeclared fields set on
+// one of tasks sort as text.
+func (a *App) sortFunc(key string, tasks []models.Task) (taskLess, error) {
+	switch key {
+	case "id":
+		return func(x, y models.Task) bool { return x.ID < y.ID }, nil
+	case "title":
+		return func(x, y models.Task) bool { return strings.ToLower(x.Title) < strings.ToLower(y.Title) }, nil
+	case "label":
+		return func(x, y models.Task) bool { return x.Label < y.Label }, nil
+	case "status":
+		order := make(map[models.Status]int)
+		for i, status := range a.workflow().Names() {
+			order[status] = i
+		}
+		return func(x, y models.Task) bool { return order[x.Status] < order[y.Status] }, nil
+	case "created":
+		return func(x, y models.Task) bool { return x.CreatedAt.Before(y.CreatedAt) }, nil
+	case "updated":
+		return func(x, y models.Task) bool { return x.UpdatedAt.Before(y.UpdatedAt) }, nil
+	}
+
+	def, ok := a.settings().Fields[key]
+	if !ok {
+		if hasField(tasks, key) {
+			return func(x, y models.Task) bool { return x.Field(key) < y.Field(key) }, nil
+		}
+		return nil, fmt.Errorf("unknown sort key: %s", key)
+	}
+
+	return func(x, y models.Task) bool { return def.Compare(x.Field(key), y.Field(key)) < 0 }, nil
+}
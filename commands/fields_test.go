@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+// newFieldsApp returns an app with a few declared custom fields
+func newFieldsApp() *App {
+	cfg := config.Default()
+	cfg.Fields = map[string]models.FieldDefinition{
+		"customer":  {Type: models.FieldString},
+		"points":    {Type: models.FieldNumber},
+		"sprint":    {Type: models.FieldDate},
+		"component": {Type: models.FieldEnum, Values: []string{"api", "ui"}},
+	}
+
+	return &App{
+		storage: storage.NewMockStorage(),
+		config:  cfg,
+	}
+}
+
+func TestHandleUpdateSetFields(t *testing.T) {
+	app := newFieldsApp()
+
+	if err := app.handleAdd([]string{"Test Task", "--set", "customer=Acme"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	// Test setting several fields at once
+	err := app.handleUpdate([]string{"1", "--set", "points=3.0", "--set", "component=api"})
+	if err != nil {
+		t.Fatalf("Expected no error when setting fields, got %v", err)
+	}
+
+	task, err := app.storage.GetTaskByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+
+	if task.Field("customer") != "Acme" || task.Field("points") != "3" || task.Field("component") != "api" {
+		t.Errorf("Unexpected fields: %v", task.Fields)
+	}
+
+	// Test unsetting a field
+	if err := app.handleUpdate([]string{"1", "--set", "customer="}); err != nil {
+		t.Fatalf("Expected no error when unsetting field, got %v", err)
+	}
+
+	task, _ = app.storage.GetTaskByID(1)
+	if task.Field("customer") != "" {
+		t.Errorf("Expected customer to be unset, got %s", task.Field("customer"))
+	}
+
+	// Test invalid assignments
+	invalid := [][]string{
+		{"1", "--set", "points=many"},
+		{"1", "--set", "component=db"},
+		{"1", "--set", "sprint=tomorrow"},
+		{"1", "--set", "unknown=value"},
+		{"1", "--set", "customer"},
+	}
+
+	for _, args := range invalid {
+		if err := app.handleUpdate(args); err == nil {
+			t.Errorf("Expected error for %v, got nil", args)
+		}
+	}
+}
+
+func TestHandleFilterFieldsAndSort(t *testing.T) {
+	app := newFieldsApp()
+
+	tasks := []struct {
+		title     string
+		points    string
+		component string
+	}{
+		{"Task 1", "5", "api"},
+		{"Task 2", "1", "ui"},
+		{"Task 3", "3", "api"},
+	}
+
+	for _, tt := range tasks {
+		err := app.handleAdd([]string{tt.title, "--set", "points=" + tt.points, "--set", "component=" + tt.component})
+		if err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	// Test filtering by a custom field
+	if err := app.handleFilter([]string{"--field", "component=api", "--sort", "points"}); err != nil {
+		t.Errorf("Expected no error when filtering by field, got %v", err)
+	}
+
+	if err := app.handleFilter([]string{"--field", "component=db"}); err == nil {
+		t.Error("Expected error when filtering by invalid enum value, got nil")
+	}
+
+	// Test filtering and sorting by an undeclared field set by an import
+	task, _ := app.storage.GetTaskByID(1)
+	task.SetField("github_issue", "12")
+	if err := app.storage.UpdateTask(task); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	if err := app.handleFilter([]string{"--field", "github_issue=12", "--sort", "github_issue"}); err != nil {
+		t.Errorf("Expected no error when filtering by an imported field, got %v", err)
+	}
+
+	if err := app.handleFilter([]string{"--field", "nobody_set=1"}); err == nil {
+		t.Error("Expected error when filtering by a field no task has, got nil")
+	}
+
+	// Test sorting by a custom field
+	all, _ := app.storage.GetTasks()
+	if err := app.sortTasks(all, "points", false); err != nil {
+		t.Fatalf("Expected no error when sorting, got %v", err)
+	}
+
+	if all[0].Title != "Task 2" || all[2].Title != "Task 1" {
+		t.Errorf("Expected tasks sorted by points, got %v", all)
+	}
+
+	if err := app.sortTasks(all, "title", true); err != nil {
+		t.Fatalf("Expected no error when sorting, got %v", err)
+	}
+
+	if all[0].Title != "Task 3" {
+		t.Errorf("Expected tasks sorted by title descending, got %v", all)
+	}
+
+	if err := app.handleList([]string{"--sort", "unknown"}); err == nil {
+		t.Error("Expected error when sorting by unknown key, got nil")
+	}
+
+	// Test formatting with a template
+	if err := app.handleList([]string{"--format", `{{.ID}} {{.Field "component"}}`}); err != nil {
+		t.Errorf("Expected no error when formatting, got %v", err)
+	}
+
+	if err := app.handleList([]string{"--format", "{{.Missing"}); err == nil {
+		t.Error("Expected error for invalid template, got nil")
+	}
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -20,10 +21,25 @@ func (a *App) handleAdd(args []string) error {
 	label := parsedArgs["label"]
 
 	if label == "" {
-		label = "task" // Default label
+		label = a.settings().DefaultLabel
 	}
 
 	task := models.NewTask(title, label)
+	task.Status = a.workflow().InitialStatus()
+
+	if err := a.applyFieldAssignments(&task, flagValues(args, "set")); err != nil {
+		return err
+	}
+
+	if value, ok := parsedArgs["estimate"]; ok {
+		if err := setEstimate(&task, value); err != nil {
+			return err
+		}
+	}
+
+	if err := setSchedule(&task, parsedArgs); err != nil {
+		return err
+	}
 
 	addedTask, err := a.storage.AddTask(task)
 	if err != nil {
@@ -41,17 +57,7 @@ func (a *App) handleList(args []string) error {
 		return fmt.Errorf("failed to get tasks: %w", err)
 	}
 
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found")
-		return nil
-	}
-
-	fmt.Println("Tasks:")
-	for _, task := range tasks {
-		fmt.Println(task)
-	}
-
-	return nil
+	return a.renderTasks("Tasks", "No tasks found", tasks, parseArgs(args))
 }
 
 // handleUpdate handles the update command
@@ -74,13 +80,18 @@ func (a *App) handleUpdate(args []string) error {
 		return fmt.Errorf("failed to get task: %w", err)
 	}
 
+	previousStatus := task.Status
+
 	// Update status if provided
 	if status, ok := parsedArgs["status"]; ok {
-		switch models.Status(status) {
-		case models.StatusTodo, models.StatusInProgress, models.StatusDone:
-			task.Status = models.Status(status)
-		default:
+		workflow := a.workflow()
+		switch newStatus := models.Status(status); {
+		case !workflow.IsValid(newStatus):
 			fmt.Printf("Invalid status: %s. Using current status: %s\n", status, task.Status)
+		case !workflow.CanTransition(task.Status, newStatus):
+			fmt.Printf("Transition from %s to %s is not allowed. Using current status: %s\n", task.Status, newStatus, task.Status)
+		default:
+			task.SetStatus(newStatus, time.Now())
 		}
 	}
 
@@ -94,17 +105,190 @@ func (a *App) handleUpdate(args []string) error {
 		task.Title = title
 	}
 
+	// Update custom fields if provided
+	if err := a.applyFieldAssignments(&task, flagValues(args, "set")); err != nil {
+		return err
+	}
+
+	// Update estimate if provided
+	if value, ok := parsedArgs["estimate"]; ok {
+		if err := setEstimate(&task, value); err != nil {
+			return err
+		}
+	}
+
+	// Update due date and recurrence if provided
+	if err := setSchedule(&task, parsedArgs); err != nil {
+		return err
+	}
+
+	// Update milestone if provided
+	if name, ok := parsedArgs["milestone"]; ok {
+		if name != "" {
+			if _, err := a.getMilestone(name); err != nil {
+				return err
+			}
+		}
+		task.Milestone = name
+	}
+
 	// Update timestamp
 	task.UpdatedAt = time.Now()
 
+	// Completing a recurring task hands its rule over to the next occurrence
+	var next *models.Task
+	workflow := a.workflow()
+	if task.Recurrence != "" && workflow.IsClosed(task.Status) && !workflow.IsClosed(previousStatus) {
+		occurrence, err := task.NextOccurrence(workflow.InitialStatus(), task.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		task.Recurrence = ""
+		next = &occurrence
+	}
+
 	if err := a.storage.UpdateTask(task); err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
 	fmt.Printf("Task successfully updated: %s\n", task)
+
+	if next != nil {
+		addedTask, err := a.storage.AddTask(*next)
+		if err != nil {
+			return fmt.Errorf("failed to add next occurrence: %w", err)
+		}
+		fmt.Printf("Next occurrence added: %s (due %s)\n", addedTask, addedTask.Due.Format(models.DateLayout))
+	}
+
+	return nil
+}
+
+// setEstimate parses and stores an estimate, clearing it when value is empty
+func setEstimate(task *models.Task, value string) error {
+	if value == "" {
+		task.Estimate = nil
+		return nil
+	}
+
+	estimate, err := models.ParseEstimate(value)
+	if err != nil {
+		return err
+	}
+
+	task.Estimate = &estimate
+	return nil
+}
+
+// setSchedule applies the --due and --recur flags, clearing them when empty
+func setSchedule(task *models.Task, parsedArgs map[string]string) error {
+	if value, ok := parsedArgs["due"]; ok {
+		if value == "" {
+			task.Due = nil
+		} else {
+			due, err := parseDate(value)
+			if err != nil {
+				return err
+			}
+			task.Due = &due
+		}
+	}
+
+	if value, ok := parsedArgs["recur"]; ok {
+		if value != "" {
+			if _, err := models.ParseRecurrence(value); err != nil {
+				return err
+			}
+		}
+		task.Recurrence = value
+	}
+
 	return nil
 }
 
+// parseDate parses a YYYY-MM-DD date at local midnight
+func parseDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// handleShow handles the show command
+func (a *App) handleShow(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Task ID is required")
+		return nil
+	}
+
+	id, err := strconv.Atoi(parseArgs(args)["main"])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	task, err := a.storage.GetTaskByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	now := time.Now()
+	tracked := task.TrackedTime(now)
+
+	fmt.Printf("Task %d: %s\n", task.ID, task.Title)
+	if task.Description != "" {
+		fmt.Printf("  Description: %s\n", task.Description)
+	}
+	fmt.Printf("  Status:      %s (%s)\n", task.Status, a.workflow().Category(task.Status))
+	fmt.Printf("  Label:       %s\n", task.Label)
+	fmt.Printf("  Created:     %s\n", task.CreatedAt.Local().Format(time.DateTime))
+	fmt.Printf("  Updated:     %s\n", task.UpdatedAt.Local().Format(time.DateTime))
+
+	for _, name := range sortedFieldNames(task.Fields) {
+		fmt.Printf("  %-12s %s\n", name+":", task.Fields[name])
+	}
+
+	fmt.Printf("  Tracked:     %s", models.FormatDuration(tracked))
+	if task.Running() {
+		fmt.Print(" (timer running)")
+	}
+	fmt.Println()
+
+	if task.Milestone != "" {
+		fmt.Printf("  Milestone:   %s\n", task.Milestone)
+	}
+	if task.Due != nil {
+		fmt.Printf("  Due:         %s\n", task.Due.Format(models.DateLayout))
+	}
+	if task.Recurrence != "" {
+		fmt.Printf("  Recurrence:  %s\n", task.Recurrence)
+	}
+
+	if task.Estimate != nil {
+		fmt.Printf("  Estimate:    %s\n", task.Estimate)
+		if !task.Estimate.IsPoints() {
+			remaining := task.Estimate.Remaining(tracked)
+			if remaining < 0 {
+				fmt.Printf("  Remaining:   %s (over estimate by %s)\n", models.FormatDuration(0), models.FormatDuration(-remaining))
+			} else {
+				fmt.Printf("  Remaining:   %s\n", models.FormatDuration(remaining))
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedFieldNames returns the names of the set custom fields in order
+func sortedFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // handleFilter handles the filter command
 func (a *App) handleFilter(args []string) error {
 	parsedArgs := parseArgs(args)
@@ -114,54 +298,51 @@ func (a *App) handleFilter(args []string) error {
 		return fmt.Errorf("failed to get tasks: %w", err)
 	}
 
-	var filteredTasks []models.Task
+	var filters []func(models.Task) bool
 
 	// Filter by label
 	if label, ok := parsedArgs["label"]; ok {
-		for _, task := range tasks {
-			if task.Label == label {
-				filteredTasks = append(filteredTasks, task)
-			}
-		}
+		filters = append(filters, func(task models.Task) bool { return task.Label == label })
 	}
 
 	// Filter by status
 	if status, ok := parsedArgs["status"]; ok {
-		// If we already filtered by label, filter the filtered tasks
-		if len(filteredTasks) > 0 {
-			var statusFilteredTasks []models.Task
-			for _, task := range filteredTasks {
-				if string(task.Status) == status {
-					statusFilteredTasks = append(statusFilteredTasks, task)
-				}
-			}
-			filteredTasks = statusFilteredTasks
-		} else {
-			// Otherwise filter all tasks
-			for _, task := range tasks {
-				if string(task.Status) == status {
-					filteredTasks = append(filteredTasks, task)
-				}
-			}
-		}
+		filters = append(filters, func(task models.Task) bool { return string(task.Status) == status })
 	}
 
-	// If no filters were applied, show all tasks
-	if len(parsedArgs) == 0 {
-		filteredTasks = tasks
+	// Filter by milestone
+	if milestone, ok := parsedArgs["milestone"]; ok {
+		filters = append(filters, func(task models.Task) bool { return task.Milestone == milestone })
 	}
 
-	if len(filteredTasks) == 0 {
-		fmt.Println("No tasks found matching the filter criteria")
-		return nil
+	// Filter by custom fields
+	for _, assignment := range flagValues(args, "field") {
+		name, value, err := a.parseFieldMatch(assignment, tasks)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, func(task models.Task) bool { return task.Field(name) == value })
 	}
 
-	fmt.Println("Filtered Tasks:")
-	for _, task := range filteredTasks {
-		fmt.Println(task)
+	// Keep the tasks matching every filter, or all tasks without filters
+	var filteredTasks []models.Task
+	for _, task := range tasks {
+		if matchesAll(task, filters) {
+			filteredTasks = append(filteredTasks, task)
+		}
 	}
 
-	return nil
+	return a.renderTasks("Filtered Tasks", "No tasks found matching the filter criteria", filteredTasks, parsedArgs)
+}
+
+// matchesAll reports whether the task satisfies every filter
+func matchesAll(task models.Task, filters []func(models.Task) bool) bool {
+	for _, filter := range filters {
+		if !filter(task) {
+			return false
+		}
+	}
+	return true
 }
 
 // handleRemove handles the remove command
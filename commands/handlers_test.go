@@ -343,3 +343,142 @@ func TestHandleRemove(t *testing.T) {
 		t.Errorf("Expected no error when removing with no task ID, got %v", err)
 	}
 }
+
+func TestHandleEstimate(t *testing.T) {
+	// Create a mock app with mock storage
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	// Test adding a task with an estimate
+	err := app.handleAdd([]string{"Test Task", "--estimate", "2h"})
+	if err != nil {
+		t.Fatalf("Expected no error when adding task with estimate, got %v", err)
+	}
+
+	task, err := app.storage.GetTaskByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+
+	if task.Estimate == nil || task.Estimate.String() != "2h" {
+		t.Errorf("Expected estimate to be 2h, got %v", task.Estimate)
+	}
+
+	// Test updating the estimate to story points
+	if err := app.handleUpdate([]string{"1", "--estimate", "5pt"}); err != nil {
+		t.Fatalf("Expected no error when updating estimate, got %v", err)
+	}
+
+	task, _ = app.storage.GetTaskByID(1)
+	if task.Estimate == nil || !task.Estimate.IsPoints() {
+		t.Errorf("Expected estimate in points, got %v", task.Estimate)
+	}
+
+	// Test clearing the estimate
+	if err := app.handleUpdate([]string{"1", "--estimate", ""}); err != nil {
+		t.Fatalf("Expected no error when clearing estimate, got %v", err)
+	}
+
+	task, _ = app.storage.GetTaskByID(1)
+	if task.Estimate != nil {
+		t.Errorf("Expected estimate to be cleared, got %v", task.Estimate)
+	}
+
+	// Test invalid estimate
+	if err := app.handleUpdate([]string{"1", "--estimate", "soon"}); err == nil {
+		t.Error("Expected error for invalid estimate, got nil")
+	}
+}
+
+func TestHandleShow(t *testing.T) {
+	// Create a mock app with mock storage
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	if err := app.handleAdd([]string{"Test Task", "--estimate", "1h"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	if err := app.handleLog([]string{"1", "90m"}); err != nil {
+		t.Fatalf("Failed to log time: %v", err)
+	}
+
+	// Test showing a task
+	if err := app.handleShow([]string{"1"}); err != nil {
+		t.Errorf("Expected no error when showing task, got %v", err)
+	}
+
+	// Test showing a non-existent task
+	if err := app.handleShow([]string{"999"}); err == nil {
+		t.Error("Expected error when showing non-existent task, got nil")
+	}
+
+	// Test showing with invalid or no task ID
+	if err := app.handleShow([]string{"invalid"}); err == nil {
+		t.Error("Expected error when showing with invalid task ID, got nil")
+	}
+
+	if err := app.handleShow([]string{}); err != nil {
+		t.Errorf("Expected no error when showing with no task ID, got %v", err)
+	}
+}
+
+func TestHandleUpdateRecurring(t *testing.T) {
+	// Create a mock app with mock storage
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	err := app.handleAdd([]string{"Rotate credentials", "--due", "2024-03-01", "--recur", "monthly"})
+	if err != nil {
+		t.Fatalf("Expected no error when adding recurring task, got %v", err)
+	}
+
+	// Test completing a recurring task spawns the next occurrence
+	if err := app.handleUpdate([]string{"1", "--status", "done"}); err != nil {
+		t.Fatalf("Expected no error when completing recurring task, got %v", err)
+	}
+
+	tasks, _ := app.storage.GetTasks()
+	if len(tasks) != 2 {
+		t.Fatalf("Expected next occurrence to be added, got %d tasks", len(tasks))
+	}
+
+	completed, _ := app.storage.GetTaskByID(1)
+	if completed.Recurrence != "" {
+		t.Errorf("Expected completed task to drop its recurrence, got %s", completed.Recurrence)
+	}
+
+	next, _ := app.storage.GetTaskByID(2)
+	if next.Status != models.StatusTodo || next.Recurrence != "monthly" {
+		t.Errorf("Unexpected next occurrence: %+v", next)
+	}
+
+	if next.Due == nil || next.Due.Format(models.DateLayout) != "2024-04-01" {
+		t.Errorf("Expected next occurrence due 2024-04-01, got %v", next.Due)
+	}
+
+	// Test completing a task without recurrence spawns nothing
+	if err := app.handleUpdate([]string{"1", "--status", "to-do"}); err != nil {
+		t.Fatalf("Expected no error when reopening task, got %v", err)
+	}
+	if err := app.handleUpdate([]string{"1", "--status", "done"}); err != nil {
+		t.Fatalf("Expected no error when completing task, got %v", err)
+	}
+
+	tasks, _ = app.storage.GetTasks()
+	if len(tasks) != 2 {
+		t.Errorf("Expected no new occurrence, got %d tasks", len(tasks))
+	}
+
+	// Test invalid due dates and rules
+	if err := app.handleUpdate([]string{"2", "--due", "next week"}); err == nil {
+		t.Error("Expected error for invalid due date, got nil")
+	}
+
+	if err := app.handleUpdate([]string{"2", "--recur", "hourly"}); err == nil {
+		t.Error("Expected error for invalid recurrence, got nil")
+	}
+}
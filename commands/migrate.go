@@ -0,0 +1,320 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+// handleMigrateStorage handles the migrate-storage command
+func (a *App) handleMigrateStorage(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	from, to := parsedArgs["from"], parsedArgs["to"]
+	if from == "" || to == "" {
+		fmt.Println("Error: --from and --to backends are required")
+		return nil
+	}
+
+	for _, backend := range []string{from, to} {
+		if !isBackend(backend) {
+			return fmt.Errorf("unknown storage backend: %s (available: %s)", backend, strings.Join(storage.Backends(), ", "))
+		}
+	}
+
+	current := a.storeBackend
+	if current == "" {
+		current = storage.BackendJSON
+	}
+	if from != current {
+		return fmt.Errorf("the task store %s uses the %s backend, not %s", a.storePath, current, from)
+	}
+	if from == to {
+		return fmt.Errorf("the task store already uses the %s backend", to)
+	}
+	if a.projectStore {
+		return fmt.Errorf("project task stores always use the %s backend", storage.BackendJSON)
+	}
+
+	target, ok := parsedArgs["path"]
+	if ok {
+		expanded, err := config.ExpandPath(target)
+		if err != nil {
+			return err
+		}
+		target = expanded
+	} else {
+		target = filepath.Join(filepath.Dir(a.storePath), storage.FileName(to))
+	}
+
+	// An encrypted store stays encrypted in its new backend
+	dst, err := storage.OpenEncrypted(to, target, a.workflow(), a.cipher)
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	result, err := storage.Migrate(dst, a.storage, false)
+	if err != nil {
+		return fmt.Errorf("failed to migrate tasks: %w", err)
+	}
+	if err := verifyMigration(result, to); err != nil {
+		return err
+	}
+
+	// The milestones file is named after the store and moves along with it
+	if _, err := copyMilestones(target, a.cipher, a.storePath, a.cipher); err != nil {
+		return err
+	}
+
+	// So does the key file of a store encrypted with one
+	if keyFile := a.settings().KeyFiles[a.storePath]; keyFile != "" {
+		if err := a.recordKeyFile(target, keyFile); err != nil {
+			return err
+		}
+	}
+
+	if err := a.switchBackend(to, target, ok); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %d tasks from %s (%s) to %s (%s)\n", result.Count, a.storePath, from, target, to)
+	fmt.Printf("The old store was kept at %s\n", a.storePath)
+
+	// Reopen the store through the updated configuration
+	context := a.context
+	if context == "" {
+		context = config.DefaultContext
+	}
+	return a.openStore(storeOptions{context: context})
+}
+
+// handleStorage handles the storage command
+func (a *App) handleStorage(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Subcommand is required (migrate)")
+		return nil
+	}
+	if args[0] != "migrate" {
+		fmt.Printf("Unknown storage subcommand: %s\n", args[0])
+		return nil
+	}
+
+	parsedArgs := parseArgs(args[1:])
+
+	fromURI, toURI := parsedArgs["from"], parsedArgs["to"]
+	if fromURI == "" || toURI == "" {
+		fmt.Println("Error: --from and --to storage URIs are required")
+		return nil
+	}
+	_, force := parsedArgs["force"]
+	_, decrypt := parsedArgs["decrypt"]
+
+	src, fromCipher, fromPath, err := a.openURI(fromURI)
+	if err != nil {
+		return err
+	}
+	dst, toCipher, toPath, err := a.openURI(toURI)
+	if err != nil {
+		return err
+	}
+	if fromPath == toPath {
+		return fmt.Errorf("source and destination are the same store: %s", fromPath)
+	}
+
+	// The tasks of an encrypted store stay encrypted in the destination
+	// unless plaintext is asked for
+	toBackend, _, _ := storage.ParseURI(toURI)
+	encrypt := fromCipher != nil && toCipher == nil && !decrypt
+	if encrypt {
+		if dst, err = a.encryptDestination(dst, toBackend, toPath, fromCipher, force); err != nil {
+			return err
+		}
+		toCipher = fromCipher
+	}
+
+	result, err := storage.Migrate(dst, src, force)
+	if err != nil {
+		return fmt.Errorf("failed to migrate tasks: %w", err)
+	}
+
+	if err := verifyMigration(result, toBackend); err != nil {
+		return err
+	}
+
+	copied, err := copyMilestones(toPath, toCipher, fromPath, fromCipher)
+	if err != nil {
+		return err
+	}
+
+	// The destination is opened with the key file of the source
+	if keyFile := a.settings().KeyFiles[fromPath]; encrypt && keyFile != "" {
+		if err := a.recordKeyFile(toPath, keyFile); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Migrated %d tasks and %d milestones from %s to %s\n", result.Count, copied, fromURI, toURI)
+	fmt.Printf("Checksum: %s\n", result.Checksum)
+
+	missing, err := danglingMilestones(dst, toPath, toCipher)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: tasks refer to milestones that do not exist in %s: %s\n", toURI, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// encryptDestination encrypts the destination store dst of a migration
+// from an encrypted store with the source's cipher and reopens it. A
+// destination holding tasks is refused first unless force is set, so that a
+// failing migration does not leave it encrypted.
+func (a *App) encryptDestination(dst storage.Storage, backend, path string, c *storage.Cipher, force bool) (storage.Storage, error) {
+	if backend == storage.BackendMarkdown {
+		return nil, errors.New("the source store is encrypted and the markdown backend cannot be: use --decrypt to migrate the tasks unencrypted")
+	}
+
+	existing, err := dst.GetTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination: %w", err)
+	}
+	if len(existing) > 0 && !force {
+		return nil, fmt.Errorf("destination already holds %d tasks, use --force to overwrite them", len(existing))
+	}
+
+	if err := storage.EncryptFile(path, c); err != nil {
+		return nil, fmt.Errorf("failed to encrypt destination: %w", err)
+	}
+
+	store, err := storage.OpenEncrypted(backend, path, a.workflow(), c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+	return store, nil
+}
+
+// openURI opens the task store named by a storage URI and returns it with
+// its cipher, or nil, and its expanded path
+func (a *App) openURI(uri string) (storage.Storage, *storage.Cipher, string, error) {
+	backend, path, err := storage.ParseURI(uri)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	path, err = config.ExpandPath(path)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	store, c, err := a.openBackend(backend, path, path)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return store, c, path, nil
+}
+
+// copyMilestones copies the milestones of the store at fromPath to the
+// store at toPath, each encrypted with its own cipher, and returns how many
+// were copied
+func copyMilestones(toPath string, toCipher *storage.Cipher, fromPath string, fromCipher *storage.Cipher) (int, error) {
+	if milestonesPath(toPath) == milestonesPath(fromPath) {
+		return 0, nil
+	}
+
+	src, err := openMilestones(fromPath, fromCipher)
+	if err != nil {
+		return 0, err
+	}
+	dst, err := openMilestones(toPath, toCipher)
+	if err != nil {
+		return 0, err
+	}
+
+	return storage.CopyMilestones(dst, src)
+}
+
+// danglingMilestones returns the milestones the tasks of store refer to that
+// do not exist next to the store at path, encrypted with c
+func danglingMilestones(store storage.Storage, path string, c *storage.Cipher) ([]string, error) {
+	milestones, err := openMilestones(path, c)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := store.GetTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		if task.Milestone == "" || seen[task.Milestone] {
+			continue
+		}
+		seen[task.Milestone] = true
+		if _, err := milestones.GetMilestone(task.Milestone); err != nil {
+			missing = append(missing, task.Milestone)
+		}
+	}
+
+	return missing, nil
+}
+
+// verifyMigration fails when a backend that keeps every attribute stored
+// tasks differently, and warns about the attributes lossy backends dropped
+func verifyMigration(result storage.MigrateResult, backend string) error {
+	if len(result.Changed) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(result.Changed))
+	for i, id := range result.Changed {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	if storage.Lossless(backend) {
+		return fmt.Errorf("verification failed: checksums of tasks %s differ after migration", strings.Join(ids, ", "))
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: the %s backend does not keep every attribute of tasks %s\n", backend, strings.Join(ids, ", "))
+	return nil
+}
+
+// switchBackend points the current context, or the global store, at the
+// migrated store in the user config file
+func (a *App) switchBackend(backend, path string, explicitPath bool) error {
+	// The default location follows the backend unless a path was configured
+	setPath := explicitPath || a.settings().StoragePath != ""
+
+	return a.updateUserConfig(func(cfg *config.Config) error {
+		if a.context != "" {
+			if cfg.Contexts == nil {
+				cfg.Contexts = make(map[string]config.Context)
+			}
+			ctx := cfg.Contexts[a.context]
+			ctx.StoragePath = path
+			ctx.Backend = backend
+			cfg.Contexts[a.context] = ctx
+			return nil
+		}
+
+		cfg.StorageBackend = backend
+		if setPath {
+			cfg.StoragePath = path
+		}
+		return nil
+	})
+}
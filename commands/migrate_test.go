@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleMigrateStorage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "migrate-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv("HOME", tempDir)
+
+	workPath := filepath.Join(tempDir, "work", "tasks.json")
+	source, err := storage.NewJSONStorage(workPath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+	source.AddTask(models.Task{Title: "Keep me", Status: models.StatusTodo, Label: "task"})
+	source.AddTask(models.Task{Title: "Drop me", Status: models.StatusTodo, Label: "task"})
+	source.DeleteTask(1)
+
+	milestones, err := openMilestones(workPath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open milestones: %v", err)
+	}
+	milestones.SaveMilestone(models.Milestone{Name: "v1"})
+
+	cfg := config.Default()
+	cfg.Contexts = map[string]config.Context{"work": {StoragePath: workPath}}
+	if err := cfg.Save(filepath.Join(tempDir, config.AppName, "config.json")); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	app := &App{
+		storage:   source,
+		config:    cfg,
+		storePath: workPath,
+		context:   "work",
+	}
+
+	// Test argument validation
+	if err := app.handleMigrateStorage([]string{"--from", "json"}); err != nil {
+		t.Errorf("Expected no error when --to is missing, got %v", err)
+	}
+
+	if err := app.handleMigrateStorage([]string{"--from", "json", "--to", "xml"}); err == nil {
+		t.Error("Expected error for unknown backend, got nil")
+	}
+
+	if err := app.handleMigrateStorage([]string{"--from", "yaml", "--to", "json"}); err == nil {
+		t.Error("Expected error when --from does not match the store backend, got nil")
+	}
+
+	if err := app.handleMigrateStorage([]string{"--from", "json", "--to", "json"}); err == nil {
+		t.Error("Expected error when migrating to the same backend, got nil")
+	}
+
+	// Test migrating the context store to YAML
+	if err := app.handleMigrateStorage([]string{"--from", "json", "--to", "yaml"}); err != nil {
+		t.Fatalf("Expected no error when migrating, got %v", err)
+	}
+
+	yamlPath := filepath.Join(tempDir, "work", "tasks.yaml")
+	if app.storePath != yamlPath || app.storeBackend != storage.BackendYAML {
+		t.Errorf("Expected app to use %s (yaml), got %s (%s)", yamlPath, app.storePath, app.storeBackend)
+	}
+
+	task, err := app.storage.GetTaskByID(2)
+	if err != nil {
+		t.Fatalf("Expected task 2 to keep its ID, got %v", err)
+	}
+
+	if task.Title != "Drop me" {
+		t.Errorf("Expected task 2 to be 'Drop me', got %s", task.Title)
+	}
+
+	if _, err := os.Stat(workPath); err != nil {
+		t.Errorf("Expected the old store to be kept, got %v", err)
+	}
+
+	saved, err := config.LoadFile(filepath.Join(tempDir, config.AppName, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+
+	work := saved.Contexts["work"]
+	if work.StoragePath != yamlPath || work.Backend != storage.BackendYAML {
+		t.Errorf("Expected work context to use %s (yaml), got %s (%s)", yamlPath, work.StoragePath, work.Backend)
+	}
+
+	// Test the milestones following a store moved to another directory
+	movedPath := filepath.Join(tempDir, "moved", "work.json")
+	if err := app.handleMigrateStorage([]string{"--from", "yaml", "--to", "json", "--path", movedPath}); err != nil {
+		t.Fatalf("Expected no error when migrating to another directory, got %v", err)
+	}
+
+	if _, err := app.milestones.GetMilestone("v1"); err != nil {
+		t.Errorf("Expected milestone to be copied with the store, got %v", err)
+	}
+
+	// Test refusing project stores
+	app.projectStore = true
+	if err := app.handleMigrateStorage([]string{"--from", "yaml", "--to", "json"}); err == nil {
+		t.Error("Expected error when migrating a project store, got nil")
+	}
+}
+
+func TestHandleStorage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	sourcePath := filepath.Join(tempDir, "tasks.json")
+	source, err := storage.NewJSONStorage(sourcePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+	source.AddTask(models.Task{Title: "First", Status: models.StatusTodo, Label: "task"})
+	source.AddTask(models.Task{Title: "Second", Status: models.StatusDone, Label: "bug", Milestone: "v1"})
+
+	milestones, err := openMilestones(sourcePath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open milestones: %v", err)
+	}
+	milestones.SaveMilestone(models.Milestone{Name: "v1"})
+
+	app := &App{storage: storage.NewMockStorage()}
+
+	fromURI := "json://" + sourcePath
+	toURI := "markdown://" + filepath.Join(tempDir, "board")
+
+	// Test argument validation
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI}); err != nil {
+		t.Errorf("Expected no error when --to is missing, got %v", err)
+	}
+
+	if err := app.handleStorage([]string{"migrate", "--from", sourcePath, "--to", toURI}); err == nil {
+		t.Error("Expected error for a URI without backend, got nil")
+	}
+
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", "yaml://" + sourcePath}); err == nil {
+		t.Error("Expected error when source and destination are the same, got nil")
+	}
+
+	// Test migrating between two stores
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", toURI}); err != nil {
+		t.Fatalf("Expected no error when migrating, got %v", err)
+	}
+
+	migrated, err := storage.NewMarkdownStorage(filepath.Join(tempDir, "board"))
+	if err != nil {
+		t.Fatalf("Failed to open migrated storage: %v", err)
+	}
+
+	tasks, err := migrated.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get migrated tasks: %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Errorf("Expected 2 migrated tasks, got %d", len(tasks))
+	}
+
+	copied, err := openMilestones(filepath.Join(tempDir, "board"), nil)
+	if err != nil {
+		t.Fatalf("Failed to open migrated milestones: %v", err)
+	}
+	if _, err := copied.GetMilestone("v1"); err != nil {
+		t.Errorf("Expected milestone to be migrated with the tasks, got %v", err)
+	}
+
+	if missing, err := danglingMilestones(migrated, filepath.Join(tempDir, "other.json"), nil); err != nil || len(missing) != 1 || missing[0] != "v1" {
+		t.Errorf("Expected dangling milestone v1 next to another store, got %v (%v)", missing, err)
+	}
+
+	// Test refusing a non-empty destination unless forced
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", toURI}); err == nil {
+		t.Error("Expected error when migrating into a non-empty store, got nil")
+	}
+
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", toURI, "--force"}); err != nil {
+		t.Errorf("Expected no error when forcing migration, got %v", err)
+	}
+}
+
+func TestHandleStorageEncrypted(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "storage-encrypted-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	t.Setenv(encryptionKeyEnv, "")
+	t.Setenv(passphraseEnv, "")
+
+	sourcePath := filepath.Join(tempDir, "tasks.json")
+	source, err := storage.NewJSONStorage(sourcePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+	source.AddTask(models.Task{Title: "Call ACME", Status: models.StatusTodo, Label: "task", Milestone: "v1"})
+
+	milestones, err := openMilestones(sourcePath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open milestones: %v", err)
+	}
+	milestones.SaveMilestone(models.Milestone{Name: "v1"})
+
+	cfg := config.Default()
+	app := &App{config: cfg, storePath: sourcePath}
+	keyFile := filepath.Join(tempDir, "task.key")
+	c, err := app.useKeyFile(keyFile)
+	if err != nil {
+		t.Fatalf("Failed to create key file: %v", err)
+	}
+	for _, path := range []string{sourcePath, milestonesPath(sourcePath)} {
+		if err := storage.EncryptFile(path, c); err != nil {
+			t.Fatalf("Failed to encrypt %s: %v", path, err)
+		}
+	}
+
+	fromURI := "json://" + sourcePath
+	destPath := filepath.Join(tempDir, "moved.yaml")
+
+	// Test the destination staying encrypted with the key of the source
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", "yaml://" + destPath}); err != nil {
+		t.Fatalf("Expected no error when migrating, got %v", err)
+	}
+
+	for _, path := range []string{destPath, milestonesPath(destPath)} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if !storage.IsEncrypted(data) || bytes.Contains(data, []byte("ACME")) {
+			t.Errorf("Expected %s to be encrypted", path)
+		}
+	}
+
+	if app.config.KeyFiles[destPath] != keyFile {
+		t.Errorf("Expected key file %s recorded for the destination, got %v", keyFile, app.config.KeyFiles)
+	}
+
+	store, _, err := (&App{config: app.config}).openBackend(storage.BackendYAML, destPath, destPath)
+	if err != nil {
+		t.Fatalf("Expected the destination to open with the recorded key file, got %v", err)
+	}
+	if tasks, _ := store.GetTasks(); len(tasks) != 1 {
+		t.Errorf("Expected 1 migrated task, got %d", len(tasks))
+	}
+
+	// Test plaintext output requiring --decrypt
+	boardURI := "markdown://" + filepath.Join(tempDir, "board")
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", boardURI}); err == nil {
+		t.Error("Expected error when migrating an encrypted store to markdown, got nil")
+	}
+
+	plainPath := filepath.Join(tempDir, "plain.json")
+	if err := app.handleStorage([]string{"migrate", "--from", fromURI, "--to", "json://" + plainPath, "--decrypt"}); err != nil {
+		t.Fatalf("Expected no error when migrating with --decrypt, got %v", err)
+	}
+	if encrypted, _ := storage.IsEncryptedFile(plainPath); encrypted {
+		t.Error("Expected the destination to be unencrypted with --decrypt")
+	}
+}
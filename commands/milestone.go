@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleMilestone handles the milestone command
+func (a *App) handleMilestone(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Subcommand is required (create, list, show, delete)")
+		return nil
+	}
+
+	if a.milestones == nil {
+		return errors.New("milestones are not available for this task store")
+	}
+
+	parsedArgs := parseArgs(args[1:])
+	name := parsedArgs["main"]
+
+	switch args[0] {
+	case "list":
+		return a.listMilestones()
+	case "create", "show", "delete":
+		if name == "" {
+			fmt.Println("Error: Milestone name is required")
+			return nil
+		}
+	default:
+		fmt.Printf("Unknown milestone subcommand: %s\n", args[0])
+		return nil
+	}
+
+	switch args[0] {
+	case "create":
+		return a.createMilestone(name, parsedArgs)
+	case "show":
+		return a.showMilestone(name)
+	default:
+		return a.deleteMilestone(name)
+	}
+}
+
+// getMilestone retrieves a milestone by name
+func (a *App) getMilestone(name string) (models.Milestone, error) {
+	if a.milestones == nil {
+		return models.Milestone{}, errors.New("milestones are not available for this task store")
+	}
+
+	milestone, err := a.milestones.GetMilestone(name)
+	if err != nil {
+		return models.Milestone{}, fmt.Errorf("failed to get milestone %s: %w", name, err)
+	}
+
+	return milestone, nil
+}
+
+// createMilestone creates a new milestone
+func (a *App) createMilestone(name string, parsedArgs map[string]string) error {
+	if _, err := a.milestones.GetMilestone(name); err == nil {
+		return fmt.Errorf("milestone already exists: %s", name)
+	}
+
+	start, err := optionalDate(parsedArgs, "start")
+	if err != nil {
+		return err
+	}
+
+	end, err := optionalDate(parsedArgs, "end")
+	if err != nil {
+		return err
+	}
+
+	milestone := models.Milestone{
+		Name:        name,
+		Description: parsedArgs["description"],
+		Start:       start,
+		End:         end,
+		CreatedAt:   time.Now(),
+	}
+
+	if milestone.Start != nil && milestone.End != nil && milestone.End.Before(*milestone.Start) {
+		return errors.New("milestone end date is before its start date")
+	}
+
+	if err := a.milestones.SaveMilestone(milestone); err != nil {
+		return fmt.Errorf("failed to save milestone: %w", err)
+	}
+
+	fmt.Printf("Milestone successfully created: %s\n", milestone)
+	return nil
+}
+
+// optionalDate parses the date given with a flag, returning nil if absent
+func optionalDate(parsedArgs map[string]string, flag string) (*time.Time, error) {
+	value, ok := parsedArgs[flag]
+	if !ok {
+		return nil, nil
+	}
+
+	date, err := parseDate(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+// listMilestones prints all milestones with their completion
+func (a *App) listMilestones() error {
+	milestones, err := a.milestones.GetMilestones()
+	if err != nil {
+		return fmt.Errorf("failed to get milestones: %w", err)
+	}
+
+	if len(milestones) == 0 {
+		fmt.Println("No milestones found")
+		return nil
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	fmt.Println("Milestones:")
+	for _, milestone := range milestones {
+		progress := models.MilestoneProgress(milestone.Name, tasks, a.workflow())
+		fmt.Printf("%s %3.0f%% (%d/%d done, %d open)\n", milestone, progress.Percent(), progress.Closed, progress.Total, len(progress.Open))
+	}
+
+	return nil
+}
+
+// showMilestone prints a milestone and its remaining open tasks
+func (a *App) showMilestone(name string) error {
+	milestone, err := a.getMilestone(name)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	progress := models.MilestoneProgress(milestone.Name, tasks, a.workflow())
+
+	fmt.Printf("Milestone: %s\n", milestone)
+	if milestone.Description != "" {
+		fmt.Printf("  Description: %s\n", milestone.Description)
+	}
+	fmt.Printf("  Progress:    %.0f%% (%d/%d done)\n", progress.Percent(), progress.Closed, progress.Total)
+
+	if milestone.End != nil && len(progress.Open) > 0 {
+		days := daysLeft(*milestone.End, time.Now())
+		if days >= 0 {
+			fmt.Printf("  Days left:   %d\n", days)
+		} else {
+			fmt.Printf("  Overdue by:  %d days\n", -days)
+		}
+	}
+
+	if len(progress.Open) == 0 {
+		fmt.Println("No open tasks remaining")
+		return nil
+	}
+
+	fmt.Println("Open tasks:")
+	for _, task := range progress.Open {
+		fmt.Println(task)
+	}
+
+	return nil
+}
+
+// deleteMilestone deletes a milestone and unassigns its tasks
+func (a *App) deleteMilestone(name string) error {
+	if err := a.milestones.DeleteMilestone(name); err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		if task.Milestone != name {
+			continue
+		}
+		task.Milestone = ""
+		if err := a.storage.UpdateTask(task); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+	}
+
+	fmt.Printf("Milestone %s successfully deleted\n", name)
+	return nil
+}
+
+// daysLeft returns the number of whole days from now until the end of the
+// last day of a milestone ending on end, negative once it is overdue
+func daysLeft(end, now time.Time) int {
+	// Floor rather than truncate so that a milestone overdue by hours counts
+	// as overdue by a day instead of having no days left
+	return int(math.Floor(end.AddDate(0, 0, 1).Sub(now).Hours() / 24))
+}
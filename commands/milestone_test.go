@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleMilestone(t *testing.T) {
+	app := &App{
+		storage:    storage.NewMockStorage(),
+		milestones: storage.NewMockMilestoneStorage(),
+	}
+
+	// Test listing without milestones
+	if err := app.handleMilestone([]string{"list"}); err != nil {
+		t.Errorf("Expected no error when listing no milestones, got %v", err)
+	}
+
+	// Test creating a milestone
+	err := app.handleMilestone([]string{"create", "sprint-1", "--start", "2024-03-01", "--end", "2024-03-14", "--description", "First sprint"})
+	if err != nil {
+		t.Fatalf("Expected no error when creating milestone, got %v", err)
+	}
+
+	milestone, err := app.milestones.GetMilestone("sprint-1")
+	if err != nil {
+		t.Fatalf("Failed to get milestone: %v", err)
+	}
+
+	if milestone.End == nil || milestone.Description != "First sprint" {
+		t.Errorf("Unexpected milestone: %+v", milestone)
+	}
+
+	// Test invalid milestones
+	if err := app.handleMilestone([]string{"create", "sprint-1"}); err == nil {
+		t.Error("Expected error when creating duplicate milestone, got nil")
+	}
+
+	if err := app.handleMilestone([]string{"create", "sprint-2", "--start", "2024-03-14", "--end", "2024-03-01"}); err == nil {
+		t.Error("Expected error when end is before start, got nil")
+	}
+
+	if err := app.handleMilestone([]string{"create", "sprint-2", "--end", "soon"}); err == nil {
+		t.Error("Expected error for invalid end date, got nil")
+	}
+
+	// Test assigning tasks
+	for _, title := range []string{"Task 1", "Task 2"} {
+		if err := app.handleAdd([]string{title}); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if err := app.handleUpdate([]string{id, "--milestone", "sprint-1"}); err != nil {
+			t.Fatalf("Expected no error when assigning milestone, got %v", err)
+		}
+	}
+
+	if err := app.handleUpdate([]string{"1", "--milestone", "unknown"}); err == nil {
+		t.Error("Expected error when assigning unknown milestone, got nil")
+	}
+
+	if err := app.handleUpdate([]string{"1", "--status", "done"}); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+
+	// Test listing and showing progress
+	if err := app.handleMilestone([]string{"list"}); err != nil {
+		t.Errorf("Expected no error when listing milestones, got %v", err)
+	}
+
+	if err := app.handleMilestone([]string{"show", "sprint-1"}); err != nil {
+		t.Errorf("Expected no error when showing milestone, got %v", err)
+	}
+
+	if err := app.handleMilestone([]string{"show", "unknown"}); err == nil {
+		t.Error("Expected error when showing unknown milestone, got nil")
+	}
+
+	if err := app.handleFilter([]string{"--milestone", "sprint-1"}); err != nil {
+		t.Errorf("Expected no error when filtering by milestone, got %v", err)
+	}
+
+	// Test deleting unassigns the tasks
+	if err := app.handleMilestone([]string{"delete", "sprint-1"}); err != nil {
+		t.Fatalf("Expected no error when deleting milestone, got %v", err)
+	}
+
+	task, _ := app.storage.GetTaskByID(2)
+	if task.Milestone != "" {
+		t.Errorf("Expected task to be unassigned, got %s", task.Milestone)
+	}
+
+	// Test missing arguments
+	if err := app.handleMilestone([]string{}); err != nil {
+		t.Errorf("Expected no error with no subcommand, got %v", err)
+	}
+
+	if err := app.handleMilestone([]string{"show"}); err != nil {
+		t.Errorf("Expected no error with no milestone name, got %v", err)
+	}
+}
+
+func TestDaysLeft(t *testing.T) {
+	end := time.Date(2024, 3, 14, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		now      time.Time
+		expected int
+	}{
+		{time.Date(2024, 3, 12, 12, 0, 0, 0, time.Local), 2},
+		{time.Date(2024, 3, 14, 18, 0, 0, 0, time.Local), 0},
+		{time.Date(2024, 3, 15, 1, 0, 0, 0, time.Local), -1},
+		{time.Date(2024, 3, 17, 9, 0, 0, 0, time.Local), -3},
+	}
+
+	for _, test := range tests {
+		if days := daysLeft(end, test.now); days != test.expected {
+			t.Errorf("Expected %d days left at %s, got %d", test.expected, test.now, days)
+		}
+	}
+}
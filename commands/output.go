@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// outputStyle returns the output style requested with --output, falling
+// back to the configured default
+func (a *App) outputStyle(parsedArgs map[string]string) string {
+	if output, ok := parsedArgs["output"]; ok {
+		return output
+	}
+	return a.settings().Output
+}
+
+// renderTasks sorts and prints a task list honouring the --sort, --reverse,
+// --format and --output flags. The empty message is printed in text output
+// when there are no tasks.
+func (a *App) renderTasks(title, empty string, tasks []models.Task, parsedArgs map[string]string) error {
+	if key, ok := parsedArgs["sort"]; ok {
+		// Sort a copy so the storage's own slice is left untouched
+		tasks = append([]models.Task(nil), tasks...)
+		if err := a.sortTasks(tasks, key, parsedArgs["reverse"] == "true"); err != nil {
+			return err
+		}
+	}
+
+	if format, ok := parsedArgs["format"]; ok {
+		return printTemplate(tasks, format)
+	}
+
+	output := a.outputStyle(parsedArgs)
+	if len(tasks) == 0 && output == config.OutputText {
+		fmt.Println(empty)
+		return nil
+	}
+
+	return printTasks(a.header(title), tasks, output)
+}
+
+// printTemplate prints every task using a text/template, e.g.
+// '{{.ID}} {{.Title}} {{.Field "customer"}}'
+func printTemplate(tasks []models.Task, format string) error {
+	tmpl, err := template.New("task").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid format: %w", err)
+	}
+
+	for _, task := range tasks {
+		if err := tmpl.Execute(os.Stdout, task); err != nil {
+			return fmt.Errorf("failed to format task %d: %w", task.ID, err)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// printTasks prints tasks in the given output style
+func printTasks(header string, tasks []models.Task, output string) error {
+	switch output {
+	case config.OutputJSON:
+		if tasks == nil {
+			tasks = []models.Task{}
+		}
+		data, err := json.MarshalIndent(tasks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal tasks: %w", err)
+		}
+		fmt.Println(string(data))
+	case config.OutputText:
+		fmt.Println(header)
+		for _, task := range tasks {
+			fmt.Println(task)
+		}
+	default:
+		return fmt.Errorf("unknown output style: %s", output)
+	}
+
+	return nil
+}
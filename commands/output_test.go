@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestPrintTasks(t *testing.T) {
+	tasks := []models.Task{models.NewTask("Test Task", "test")}
+
+	if err := printTasks("Tasks:", tasks, config.OutputText); err != nil {
+		t.Errorf("Expected no error for text output, got %v", err)
+	}
+
+	if err := printTasks("Tasks:", nil, config.OutputJSON); err != nil {
+		t.Errorf("Expected no error for JSON output, got %v", err)
+	}
+
+	if err := printTasks("Tasks:", tasks, "xml"); err == nil {
+		t.Error("Expected error for unknown output style, got nil")
+	}
+}
@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleReport handles the report command
+func (a *App) handleReport(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Report name is required (estimates, burndown, throughput)")
+		return nil
+	}
+
+	switch args[0] {
+	case "estimates":
+		return a.reportEstimates(args[1:])
+	case "burndown":
+		return a.reportBurndown(args[1:])
+	case "throughput":
+		return a.reportThroughput(args[1:])
+	default:
+		fmt.Printf("Unknown report: %s\n", args[0])
+		return nil
+	}
+}
+
+// reportEstimates prints estimate vs. tracked time for every estimated task
+func (a *App) reportEstimates(args []string) error {
+	parsedArgs := parseArgs(args)
+	overOnly := parsedArgs["over"] == "true"
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	now := time.Now()
+	var rows []models.Task
+	for _, task := range tasks {
+		if task.Estimate == nil || (overOnly && !task.IsOverEstimate(now)) {
+			continue
+		}
+		rows = append(rows, task)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No estimated tasks found")
+		return nil
+	}
+
+	fmt.Printf("%-5s %-10s %-10s %-10s %s\n", "ID", "ESTIMATE", "TRACKED", "REMAINING", "TITLE")
+	for _, task := range rows {
+		tracked := task.TrackedTime(now)
+
+		remaining := "-"
+		if !task.Estimate.IsPoints() {
+			remaining = models.FormatDuration(task.Estimate.Remaining(tracked))
+		}
+
+		title := task.Title
+		if task.IsOverEstimate(now) {
+			title += " (OVER)"
+		}
+
+		fmt.Printf("%-5d %-10s %-10s %-10s %s\n", task.ID, task.Estimate, models.FormatDuration(tracked), remaining, title)
+	}
+
+	return nil
+}
+
+// chartRow is a labelled value of a bar chart
+type chartRow struct {
+	label string
+	value int
+}
+
+// chartWidth is the width of the longest bar in a chart
+const chartWidth = 50
+
+// printBarChart prints rows as a horizontal ASCII bar chart
+func printBarChart(rows []chartRow) {
+	maxValue := 0
+	for _, row := range rows {
+		if row.value > maxValue {
+			maxValue = row.value
+		}
+	}
+
+	for _, row := range rows {
+		width := 0
+		if maxValue > 0 {
+			width = row.value * chartWidth / maxValue
+		}
+		if width == 0 && row.value > 0 {
+			width = 1
+		}
+		fmt.Printf("%-10s | %s %d\n", row.label, strings.Repeat("#", width), row.value)
+	}
+}
+
+// reportBurndown prints the number of open tasks at the end of each day of a
+// milestone or date range
+func (a *App) reportBurndown(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	from, to := today.AddDate(0, 0, -13), today
+	title := "Burndown"
+
+	if name, ok := parsedArgs["milestone"]; ok {
+		milestone, err := a.getMilestone(name)
+		if err != nil {
+			return err
+		}
+
+		var milestoneTasks []models.Task
+		for _, task := range tasks {
+			if task.Milestone == name {
+				milestoneTasks = append(milestoneTasks, task)
+			}
+		}
+		tasks = milestoneTasks
+
+		if milestone.Start != nil {
+			from = startOfDay(*milestone.Start)
+		}
+		if milestone.End != nil {
+			to = startOfDay(*milestone.End)
+		}
+		title = fmt.Sprintf("Burndown %s", milestone.Name)
+	}
+
+	if from, err = dateFlag(parsedArgs, "from", from); err != nil {
+		return err
+	}
+	if to, err = dateFlag(parsedArgs, "to", to); err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return errors.New("end of the range is before its start")
+	}
+
+	fmt.Printf("%s (%s - %s)\n", title, from.Format(models.DateLayout), to.Format(models.DateLayout))
+
+	// Days in the future have nothing to show yet
+	if to.After(today) {
+		to = today
+	}
+
+	workflow := a.workflow()
+	var rows []chartRow
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		open := 0
+		for _, task := range tasks {
+			if task.IsOpenAt(endOfDay, workflow) {
+				open++
+			}
+		}
+		rows = append(rows, chartRow{label: day.Format(models.DateLayout), value: open})
+	}
+
+	printBarChart(rows)
+	return nil
+}
+
+// reportThroughput prints the number of tasks completed per week
+func (a *App) reportThroughput(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	weeks := 8
+	if value, ok := parsedArgs["weeks"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of weeks: %s", value)
+		}
+		weeks = n
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Weeks start on Monday
+	today := startOfDay(time.Now())
+	thisWeek := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	first := thisWeek.AddDate(0, 0, -7*(weeks-1))
+
+	counts := make([]int, weeks)
+	workflow := a.workflow()
+	for _, task := range tasks {
+		completed, ok := task.CompletedAt(workflow)
+		if !ok || completed.Before(first) {
+			continue
+		}
+		// Round whole days so DST changes do not shift completions between weeks
+		days := int(math.Round(startOfDay(completed).Sub(first).Hours() / 24))
+		if week := days / 7; week < weeks {
+			counts[week]++
+		}
+	}
+
+	fmt.Println("Throughput (tasks completed per week)")
+	rows := make([]chartRow, weeks)
+	for i := range rows {
+		year, week := first.AddDate(0, 0, 7*i).ISOWeek()
+		rows[i] = chartRow{label: fmt.Sprintf("%d-W%02d", year, week), value: counts[i]}
+	}
+
+	printBarChart(rows)
+	return nil
+}
+
+// dateFlag parses the date given with a flag, returning fallback if absent
+func dateFlag(parsedArgs map[string]string, flag string, fallback time.Time) (time.Time, error) {
+	value, ok := parsedArgs[flag]
+	if !ok {
+		return fallback, nil
+	}
+	return parseDate(value)
+}
+
+// startOfDay truncates t to local midnight
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleReportEstimates(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	// Test the report without estimated tasks
+	if err := app.handleReport([]string{"estimates"}); err != nil {
+		t.Errorf("Expected no error without estimated tasks, got %v", err)
+	}
+
+	if err := app.handleAdd([]string{"Task 1", "--estimate", "1h"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	if err := app.handleAdd([]string{"Task 2", "--estimate", "3pt"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	if err := app.handleLog([]string{"1", "2h"}); err != nil {
+		t.Fatalf("Failed to log time: %v", err)
+	}
+
+	for _, args := range [][]string{{"estimates"}, {"estimates", "--over"}} {
+		if err := app.handleReport(args); err != nil {
+			t.Errorf("Expected no error for report %v, got %v", args, err)
+		}
+	}
+
+	// Test unknown and missing reports
+	if err := app.handleReport([]string{"unknown"}); err != nil {
+		t.Errorf("Expected no error for unknown report, got %v", err)
+	}
+
+	if err := app.handleReport([]string{}); err != nil {
+		t.Errorf("Expected no error for missing report, got %v", err)
+	}
+}
+
+func TestHandleReportBurndownAndThroughput(t *testing.T) {
+	app := &App{
+		storage:    storage.NewMockStorage(),
+		milestones: storage.NewMockMilestoneStorage(),
+	}
+
+	now := time.Now()
+	start := now.AddDate(0, 0, -5)
+	end := now.AddDate(0, 0, 5)
+	if err := app.milestones.SaveMilestone(models.Milestone{Name: "sprint-1", Start: &start, End: &end}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		task := models.NewTask("Task", "test")
+		task.CreatedAt = start
+		task.Milestone = "sprint-1"
+		if i == 0 {
+			task.SetStatus(models.StatusDone, now.AddDate(0, 0, -2))
+		}
+		if _, err := app.storage.AddTask(task); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	reports := [][]string{
+		{"burndown"},
+		{"burndown", "--milestone", "sprint-1"},
+		{"burndown", "--from", "2024-03-01", "--to", "2024-03-07"},
+		{"throughput"},
+		{"throughput", "--weeks", "2"},
+	}
+
+	for _, args := range reports {
+		if err := app.handleReport(args); err != nil {
+			t.Errorf("Expected no error for report %v, got %v", args, err)
+		}
+	}
+
+	invalid := [][]string{
+		{"burndown", "--milestone", "unknown"},
+		{"burndown", "--from", "2024-03-07", "--to", "2024-03-01"},
+		{"burndown", "--from", "soon"},
+		{"throughput", "--weeks", "0"},
+	}
+
+	for _, args := range invalid {
+		if err := app.handleReport(args); err == nil {
+			t.Errorf("Expected error for report %v, got nil", args)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleStandup handles the standup command
+func (a *App) handleStandup(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	value := parsedArgs["since"]
+	if value == "" {
+		value = "yesterday"
+	}
+
+	now := time.Now()
+	since, err := parseSince(value, now)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	report := buildStandup(tasks, a.workflow(), since)
+
+	fmt.Printf("## Standup (since %s)\n", since.Format("2006-01-02 15:04"))
+	printStandupSection("Completed", report.completed)
+	printStandupSection("Started", report.started)
+	printStandupSection("Created", report.created)
+
+	return nil
+}
+
+// standupReport holds the tasks that changed within a standup window
+type standupReport struct {
+	completed []models.Task
+	started   []models.Task
+	created   []models.Task
+}
+
+// buildStandup collects the tasks completed, started and created since the
+// given time. A task completed within the window is not also listed as started.
+func buildStandup(tasks []models.Task, workflow *models.Workflow, since time.Time) standupReport {
+	var report standupReport
+
+	for _, task := range tasks {
+		if doneAt, ok := task.CompletedAt(workflow); ok && !doneAt.Before(since) {
+			report.completed = append(report.completed, task)
+		} else if startedSince(task, workflow, since) {
+			report.started = append(report.started, task)
+		}
+
+		if !task.CreatedAt.Before(since) {
+			report.created = append(report.created, task)
+		}
+	}
+
+	return report
+}
+
+// startedSince reports whether the task moved into an active status since
+// the given time, relying on UpdatedAt for tasks without status history
+func startedSince(task models.Task, workflow *models.Workflow, since time.Time) bool {
+	if len(task.History) == 0 {
+		return workflow.Category(task.Status) == models.CategoryActive && !task.UpdatedAt.Before(since)
+	}
+
+	for _, change := range task.History {
+		if change.At.Before(since) {
+			continue
+		}
+		if workflow.Category(change.To) == models.CategoryActive && workflow.Category(change.From) != models.CategoryActive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printStandupSection prints a markdown section listing tasks
+func printStandupSection(title string, tasks []models.Task) {
+	fmt.Printf("\n### %s\n", title)
+
+	if len(tasks) == 0 {
+		fmt.Println("- _None_")
+		return
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("- #%d %s (%s, %s)\n", task.ID, task.Title, task.Label, task.Status)
+	}
+}
+
+// parseSince parses the start of a reporting window: "today", "yesterday",
+// a YYYY-MM-DD date or a duration back from now such as "36h" or "3d"
+func parseSince(value string, now time.Time) (time.Time, error) {
+	today := startOfDay(now)
+
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if date, err := parseDate(value); err == nil {
+		return date, nil
+	}
+
+	if n, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && strings.HasSuffix(value, "d") && n >= 0 {
+		return today.AddDate(0, 0, -n), nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected today, yesterday, YYYY-MM-DD or a duration like 3d", value)
+}
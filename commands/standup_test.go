@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 5, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"today", time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)},
+		{"yesterday", time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"3d", time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local)},
+		{"36h", now.Add(-36 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if err != nil {
+			t.Errorf("parseSince(%q): unexpected error %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("parseSince(%q): expected %s, got %s", tt.value, tt.expected, got)
+		}
+	}
+
+	for _, value := range []string{"last week", "-3d", ""} {
+		if _, err := parseSince(value, now); err == nil {
+			t.Errorf("parseSince(%q): expected error, got nil", value)
+		}
+	}
+}
+
+func TestBuildStandup(t *testing.T) {
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	before := since.Add(-48 * time.Hour)
+	after := since.Add(2 * time.Hour)
+	workflow := models.DefaultWorkflow()
+
+	completed := models.Task{ID: 1, Status: models.StatusTodo, CreatedAt: before, UpdatedAt: before}
+	completed.SetStatus(models.StatusInProgress, after)
+	completed.SetStatus(models.StatusDone, after.Add(time.Hour))
+
+	started := models.Task{ID: 2, Status: models.StatusTodo, CreatedAt: before, UpdatedAt: before}
+	started.SetStatus(models.StatusInProgress, after)
+
+	created := models.Task{ID: 3, Status: models.StatusTodo, CreatedAt: after, UpdatedAt: after}
+
+	untouched := models.Task{ID: 4, Status: models.StatusInProgress, CreatedAt: before, UpdatedAt: before}
+
+	report := buildStandup([]models.Task{completed, started, created, untouched}, workflow, since)
+
+	if len(report.completed) != 1 || report.completed[0].ID != 1 {
+		t.Errorf("Expected task 1 completed, got %v", report.completed)
+	}
+
+	if len(report.started) != 1 || report.started[0].ID != 2 {
+		t.Errorf("Expected task 2 started, got %v", report.started)
+	}
+
+	if len(report.created) != 1 || report.created[0].ID != 3 {
+		t.Errorf("Expected task 3 created, got %v", report.created)
+	}
+}
+
+func TestHandleStandup(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	if err := app.handleAdd([]string{"Test Task"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	for _, args := range [][]string{{}, {"--since", "today"}, {"--since", "2024-03-01"}} {
+		if err := app.handleStandup(args); err != nil {
+			t.Errorf("Expected no error for standup %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleStandup([]string{"--since", "soon"}); err == nil {
+		t.Error("Expected error for invalid window, got nil")
+	}
+}
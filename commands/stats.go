@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// taskStats summarises a task store
+type taskStats struct {
+	Total              int            `json:"total"`
+	ByStatus           map[string]int `json:"by_status"`
+	ByLabel            map[string]int `json:"by_label"`
+	Open               int            `json:"open"`
+	AverageOpenAgeDays float64        `json:"average_open_age_days"`
+	AverageLeadDays    float64        `json:"average_lead_time_days"`
+	Oldest             []statsTask    `json:"oldest_open"`
+	StaleDays          int            `json:"stale_days"`
+	Stale              []statsTask    `json:"stale"`
+}
+
+// statsTask is a task listed in the statistics
+type statsTask struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// handleStats handles the stats command
+func (a *App) handleStats(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	staleDays := 14
+	if value, ok := parsedArgs["stale"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid number of days: %s", value)
+		}
+		staleDays = n
+	}
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	stats := computeStats(tasks, a.workflow(), time.Now(), staleDays, 5)
+
+	switch output := a.outputStyle(parsedArgs); output {
+	case config.OutputJSON:
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal statistics: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case config.OutputText:
+		printStats(stats, a.workflow())
+		return nil
+	default:
+		return fmt.Errorf("unknown output style: %s", output)
+	}
+}
+
+// computeStats gathers the statistics of tasks, listing at most top oldest tasks
+func computeStats(tasks []models.Task, workflow *models.Workflow, now time.Time, staleDays, top int) taskStats {
+	stats := taskStats{
+		Total:     len(tasks),
+		ByStatus:  make(map[string]int),
+		ByLabel:   make(map[string]int),
+		Oldest:    []statsTask{},
+		StaleDays: staleDays,
+		Stale:     []statsTask{},
+	}
+
+	var openAge, leadTime time.Duration
+	var open []models.Task
+	completed := 0
+
+	for _, task := range tasks {
+		stats.ByStatus[string(task.Status)]++
+		stats.ByLabel[task.Label]++
+
+		if doneAt, ok := task.CompletedAt(workflow); ok {
+			leadTime += doneAt.Sub(task.CreatedAt)
+			completed++
+			continue
+		}
+
+		open = append(open, task)
+		openAge += now.Sub(task.CreatedAt)
+		if now.Sub(task.UpdatedAt) > time.Duration(staleDays)*24*time.Hour {
+			stats.Stale = append(stats.Stale, newStatsTask(task))
+		}
+	}
+
+	stats.Open = len(open)
+	if len(open) > 0 {
+		stats.AverageOpenAgeDays = days(openAge / time.Duration(len(open)))
+	}
+	if completed > 0 {
+		stats.AverageLeadDays = days(leadTime / time.Duration(completed))
+	}
+
+	sort.SliceStable(open, func(i, j int) bool { return open[i].CreatedAt.Before(open[j].CreatedAt) })
+	for i := 0; i < len(open) && i < top; i++ {
+		stats.Oldest = append(stats.Oldest, newStatsTask(open[i]))
+	}
+
+	return stats
+}
+
+// newStatsTask converts a task for the statistics
+func newStatsTask(task models.Task) statsTask {
+	return statsTask{
+		ID:        task.ID,
+		Title:     task.Title,
+		Status:    string(task.Status),
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+}
+
+// days converts a duration to days rounded to one decimal
+func days(d time.Duration) float64 {
+	return math.Round(d.Hours()/24*10) / 10
+}
+
+// printStats prints the statistics as text
+func printStats(stats taskStats, workflow *models.Workflow) {
+	fmt.Printf("Tasks: %d (%d open)\n", stats.Total, stats.Open)
+
+	fmt.Println("\nBy status:")
+	for _, status := range workflow.Names() {
+		fmt.Printf("  %-15s %d\n", status, stats.ByStatus[string(status)])
+	}
+	// Statuses no longer in the workflow are still counted
+	for _, status := range sortedCountKeys(stats.ByStatus) {
+		if !workflow.IsValid(models.Status(status)) {
+			fmt.Printf("  %-15s %d\n", status, stats.ByStatus[status])
+		}
+	}
+
+	fmt.Println("\nBy label:")
+	for _, label := range sortedCountKeys(stats.ByLabel) {
+		fmt.Printf("  %-15s %d\n", label, stats.ByLabel[label])
+	}
+
+	fmt.Printf("\nAverage age of open tasks: %.1f days\n", stats.AverageOpenAgeDays)
+	fmt.Printf("Average time to done:      %.1f days\n", stats.AverageLeadDays)
+
+	if len(stats.Oldest) > 0 {
+		fmt.Println("\nOldest open tasks:")
+		for _, task := range stats.Oldest {
+			fmt.Printf("  %d. %s (created %s)\n", task.ID, task.Title, task.CreatedAt.Local().Format(models.DateLayout))
+		}
+	}
+
+	if len(stats.Stale) > 0 {
+		fmt.Printf("\nUntouched for more than %d days:\n", stats.StaleDays)
+		for _, task := range stats.Stale {
+			fmt.Printf("  %d. %s (updated %s)\n", task.ID, task.Title, task.UpdatedAt.Local().Format(models.DateLayout))
+		}
+	}
+}
+
+// sortedCountKeys returns the keys of a count map in ascending order
+func sortedCountKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
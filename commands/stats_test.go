@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	oldest := models.Task{ID: 1, Title: "Oldest", Status: models.StatusTodo, Label: "bug",
+		CreatedAt: now.AddDate(0, 0, -30), UpdatedAt: now.AddDate(0, 0, -20)}
+	recent := models.Task{ID: 2, Title: "Recent", Status: models.StatusInProgress, Label: "feature",
+		CreatedAt: now.AddDate(0, 0, -10), UpdatedAt: now.AddDate(0, 0, -1)}
+	done := models.Task{ID: 3, Title: "Done", Status: models.StatusTodo, Label: "bug",
+		CreatedAt: now.AddDate(0, 0, -8), UpdatedAt: now.AddDate(0, 0, -8)}
+	done.SetStatus(models.StatusDone, now.AddDate(0, 0, -4))
+
+	stats := computeStats([]models.Task{recent, oldest, done}, models.DefaultWorkflow(), now, 14, 1)
+
+	if stats.Total != 3 || stats.Open != 2 {
+		t.Errorf("Expected 3 tasks with 2 open, got %d/%d", stats.Total, stats.Open)
+	}
+
+	if stats.ByStatus["done"] != 1 || stats.ByLabel["bug"] != 2 {
+		t.Errorf("Unexpected counts: %v %v", stats.ByStatus, stats.ByLabel)
+	}
+
+	if stats.AverageOpenAgeDays != 20 {
+		t.Errorf("Expected average open age of 20 days, got %.1f", stats.AverageOpenAgeDays)
+	}
+
+	if stats.AverageLeadDays != 4 {
+		t.Errorf("Expected average time to done of 4 days, got %.1f", stats.AverageLeadDays)
+	}
+
+	if len(stats.Oldest) != 1 || stats.Oldest[0].ID != 1 {
+		t.Errorf("Expected oldest open task to be 1, got %v", stats.Oldest)
+	}
+
+	if len(stats.Stale) != 1 || stats.Stale[0].ID != 1 {
+		t.Errorf("Expected task 1 to be stale, got %v", stats.Stale)
+	}
+}
+
+func TestHandleStats(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	// Test statistics of an empty store
+	if err := app.handleStats([]string{}); err != nil {
+		t.Errorf("Expected no error for empty store, got %v", err)
+	}
+
+	if err := app.handleAdd([]string{"Test Task"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	for _, args := range [][]string{{}, {"--stale", "7"}, {"--output", "json"}} {
+		if err := app.handleStats(args); err != nil {
+			t.Errorf("Expected no error for stats %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleStats([]string{"--stale", "never"}); err == nil {
+		t.Error("Expected error for invalid stale days, got nil")
+	}
+
+	if err := app.handleStats([]string{"--output", "xml"}); err == nil {
+		t.Error("Expected error for unknown output style, got nil")
+	}
+}
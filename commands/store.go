@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+// storeOptions holds the global flags selecting the task store
+type storeOptions struct {
+	global  bool
+	context string
+}
+
+// storeLocation identifies a task store and how it was selected
+type storeLocation struct {
+	path    string
+	backend string
+	context string
+	project bool
+}
+
+// extractStoreOptions removes the global store flags from args
+func extractStoreOptions(args []string) ([]string, storeOptions) {
+	var opts storeOptions
+	result := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--global":
+			opts.global = true
+		case args[i] == "--context" && i+1 < len(args):
+			opts.context = args[i+1]
+			i++
+		default:
+			result = append(result, args[i])
+		}
+	}
+
+	return result, opts
+}
+
+// resolveStore returns the task store to use, in order of precedence: the
+// context given with --context, the nearest project store found by walking
+// up from the working directory (skipped with --global), the current context
+// and finally the configured global store
+func (a *App) resolveStore(opts storeOptions) (storeLocation, error) {
+	cfg := a.settings()
+
+	if opts.context != "" {
+		return contextStore(cfg, opts.context)
+	}
+
+	if !opts.global {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return storeLocation{}, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		if path := config.FindProjectStore(cwd); path != "" {
+			return storeLocation{path: path, project: true}, nil
+		}
+	}
+
+	location, err := contextStore(cfg, cfg.ActiveContext())
+	if err != nil {
+		// A stale current context must not lock the user out of every command
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the %s context\n", err, config.DefaultContext)
+		return contextStore(cfg, config.DefaultContext)
+	}
+
+	return location, nil
+}
+
+// contextStore returns the task store of the named context
+func contextStore(cfg *config.Config, name string) (storeLocation, error) {
+	if name == config.DefaultContext {
+		path, err := cfg.ResolveStoragePath()
+		if err != nil {
+			return storeLocation{}, err
+		}
+		return storeLocation{path: path, backend: cfg.StorageBackend}, nil
+	}
+
+	ctx, ok := cfg.Contexts[name]
+	if !ok {
+		return storeLocation{}, fmt.Errorf("unknown context: %s", name)
+	}
+
+	path, err := config.ExpandPath(ctx.StoragePath)
+	if err != nil {
+		return storeLocation{}, err
+	}
+
+	return storeLocation{path: path, backend: ctx.Backend, context: name}, nil
+}
+
+// openStore opens the task store selected by opts
+func (a *App) openStore(opts storeOptions) error {
+	location, err := a.resolveStore(opts)
+	if err != nil {
+		return err
+	}
+
+	// Create data directory
+	if err := os.MkdirAll(filepath.Dir(location.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	store, c, err := a.openBackend(location.backend, location.path, location.path)
+	if err != nil {
+		return err
+	}
+
+	backups, err := a.newBackups(location.path)
+	if err != nil {
+		return err
+	}
+
+	milestones, err := openMilestones(location.path, c)
+	if err != nil {
+		return err
+	}
+
+	a.storage = storage.NewBackupStorage(store, backups)
+	a.backups = backups
+	a.cipher = c
+	a.milestones = milestones
+	a.storePath = location.path
+	a.storeBackend = location.backend
+	a.context = location.context
+	a.projectStore = location.project
+	return nil
+}
+
+// newBackups creates the backup manager of the task store at storePath,
+// backing its milestones up along with it
+func (a *App) newBackups(storePath string) (*storage.Backups, error) {
+	policy, err := a.settings().BackupPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := config.BackupDir(storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewBackups(storePath, dir, policy, milestonesPath(storePath)), nil
+}
+
+// milestonesPath returns the milestones file kept next to a task store and
+// named after it, so that stores sharing a directory keep their own
+func milestonesPath(storePath string) string {
+	base := filepath.Base(storePath)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	return filepath.Join(filepath.Dir(storePath), stem+".milestones.json")
+}
+
+// openMilestones opens the milestones of a task store, encrypted with c like
+// the store
+func openMilestones(storePath string, c *storage.Cipher) (*storage.JSONMilestoneStorage, error) {
+	milestones, err := storage.NewEncryptedJSONMilestoneStorage(milestonesPath(storePath), c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open milestone storage: %w", err)
+	}
+	return milestones, nil
+}
+
+// header formats a list header, naming the active context if any
+func (a *App) header(title string) string {
+	if a.context == "" {
+		return title + ":"
+	}
+	return fmt.Sprintf("%s [context: %s]:", title, a.context)
+}
+
+// handleInit handles the init command
+func (a *App) handleInit(args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	// Place the store at the repository root when inside a repository
+	root := config.FindRepoRoot(cwd)
+	if root == "" {
+		root = cwd
+	}
+
+	storePath := filepath.Join(root, config.ProjectDir, "tasks.json")
+	if _, err := os.Stat(storePath); err == nil {
+		fmt.Printf("Project task store already exists: %s\n", storePath)
+		return nil
+	}
+
+	projectStorage, err := storage.NewJSONStorage(storePath)
+	if err != nil {
+		return fmt.Errorf("failed to create project store: %w", err)
+	}
+
+	milestones, err := openMilestones(storePath, nil)
+	if err != nil {
+		return err
+	}
+
+	backups, err := a.newBackups(storePath)
+	if err != nil {
+		return err
+	}
+
+	a.storage = storage.NewBackupStorage(projectStorage, backups)
+	a.backups = backups
+	a.milestones = milestones
+	a.storePath = storePath
+	a.storeBackend = storage.BackendJSON
+	a.projectStore = true
+	a.cipher = nil
+
+	fmt.Printf("Initialized project task store in %s\n", filepath.Dir(storePath))
+	return nil
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestExtractStoreOptions(t *testing.T) {
+	args, opts := extractStoreOptions([]string{"issue-tracker", "list", "--global"})
+
+	if !opts.global {
+		t.Error("Expected global option to be set")
+	}
+
+	if len(args) != 2 || args[1] != "list" {
+		t.Errorf("Expected global flag to be removed, got %v", args)
+	}
+
+	_, opts = extractStoreOptions([]string{"issue-tracker", "list"})
+	if opts.global {
+		t.Error("Expected global option to be unset")
+	}
+
+	args, opts = extractStoreOptions([]string{"issue-tracker", "--context", "work", "list"})
+	if opts.context != "work" {
+		t.Errorf("Expected context option to be 'work', got %s", opts.context)
+	}
+
+	if len(args) != 2 || args[1] != "list" {
+		t.Errorf("Expected context flag to be removed, got %v", args)
+	}
+}
+
+func TestHandleInit(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "init-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	t.Setenv("HOME", filepath.Join(tempDir, "home"))
+
+	// Resolve symlinks so paths compare equal to os.Getwd
+	tempDir, err = filepath.EvalSymlinks(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve temp directory: %v", err)
+	}
+
+	nestedDir := filepath.Join(tempDir, "src")
+	if err := os.MkdirAll(filepath.Join(tempDir, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git directory: %v", err)
+	}
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(nestedDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.StoragePath = filepath.Join(tempDir, "global", "tasks.json")
+	app := &App{config: cfg}
+
+	// Test initializing the project store at the repository root
+	if err := app.handleInit([]string{}); err != nil {
+		t.Fatalf("Expected no error when initializing project store, got %v", err)
+	}
+
+	expected := filepath.Join(tempDir, config.ProjectDir, "tasks.json")
+	if _, err := os.Stat(expected); err != nil {
+		t.Fatalf("Expected project store to be created at %s: %v", expected, err)
+	}
+
+	// Test that writes right after init are backed up outside the repository
+	if _, err := app.storage.AddTask(models.Task{Title: "First", Status: models.StatusTodo}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	backups, err := app.backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Errorf("Expected a backup before the first write, got %d", len(backups))
+	}
+	if strings.HasPrefix(app.backups.Dir(), tempDir+string(filepath.Separator)+config.ProjectDir) {
+		t.Errorf("Expected backups outside the project store, got %s", app.backups.Dir())
+	}
+
+	// Test initializing again
+	if err := app.handleInit([]string{}); err != nil {
+		t.Errorf("Expected no error when project store exists, got %v", err)
+	}
+
+	// Test that the project store is discovered
+	if err := app.openStore(storeOptions{}); err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	if app.storePath != expected {
+		t.Errorf("Expected project store %s, got %s", expected, app.storePath)
+	}
+
+	// Test forcing the global store
+	if err := app.Run([]string{"issue-tracker", "list", "--global"}); err != nil {
+		t.Fatalf("Expected no error when listing global store, got %v", err)
+	}
+	if app.storePath != cfg.StoragePath {
+		t.Errorf("Expected global store %s, got %s", cfg.StoragePath, app.storePath)
+	}
+}
+
+func TestOpenMilestones(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "milestones-path-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if path := milestonesPath(filepath.Join(tempDir, "work.json")); path != filepath.Join(tempDir, "work.milestones.json") {
+		t.Errorf("Expected milestones file named after the store, got %s", path)
+	}
+
+	// Test stores in one directory keeping their own milestones
+	global, err := openMilestones(filepath.Join(tempDir, "tasks.json"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error when opening milestones, got %v", err)
+	}
+	if err := global.SaveMilestone(models.Milestone{Name: "v1"}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	work, err := openMilestones(filepath.Join(tempDir, "work.json"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error when opening milestones, got %v", err)
+	}
+	if err := work.SaveMilestone(models.Milestone{Name: "v2"}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	if _, err := work.GetMilestone("v1"); err == nil {
+		t.Error("Expected milestone of another store to be absent, got nil")
+	}
+	if _, err := global.GetMilestone("v2"); err == nil {
+		t.Error("Expected milestone created in another store to be absent, got nil")
+	}
+}
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleStart handles the start command
+func (a *App) handleStart(args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Error: Task ID is required")
+		return nil
+	}
+
+	id, err := strconv.Atoi(parseArgs(args)["main"])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	task, err := a.storage.GetTaskByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.Running() {
+		fmt.Printf("Timer is already running for task %d\n", task.ID)
+		return nil
+	}
+
+	now := time.Now()
+
+	// Only one timer runs at a time
+	if err := a.stopRunningTimers(now, task.ID); err != nil {
+		return err
+	}
+
+	if err := task.StartTimer(now); err != nil {
+		return err
+	}
+
+	a.moveToActive(&task, now)
+	task.UpdatedAt = now
+
+	if err := a.storage.UpdateTask(task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	fmt.Printf("Timer started: %s\n", task)
+	return nil
+}
+
+// moveToActive moves a task that is not being worked on to the first active
+// status, if the workflow allows it
+func (a *App) moveToActive(task *models.Task, now time.Time) {
+	workflow := a.workflow()
+	if workflow.Category(task.Status) == models.CategoryActive {
+		return
+	}
+
+	target := models.StatusInProgress
+	if workflow.Category(target) != models.CategoryActive {
+		var ok bool
+		if target, ok = workflow.FirstInCategory(models.CategoryActive); !ok {
+			return
+		}
+	}
+
+	if !workflow.CanTransition(task.Status, target) {
+		fmt.Printf("Transition from %s to %s is not allowed. Keeping status: %s\n", task.Status, target, task.Status)
+		return
+	}
+
+	task.SetStatus(target, now)
+}
+
+// handleStop handles the stop command
+func (a *App) handleStop(args []string) error {
+	now := time.Now()
+
+	if len(args) == 0 {
+		return a.stopRunningTimers(now, 0)
+	}
+
+	id, err := strconv.Atoi(parseArgs(args)["main"])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	task, err := a.storage.GetTaskByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !task.Running() {
+		fmt.Printf("No timer is running for task %d\n", task.ID)
+		return nil
+	}
+
+	return a.stopTimer(task, now)
+}
+
+// stopRunningTimers stops the timers of all tasks except the given one
+func (a *App) stopRunningTimers(now time.Time, exceptID int) error {
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	stopped := 0
+	for _, task := range tasks {
+		if task.ID == exceptID || !task.Running() {
+			continue
+		}
+		if err := a.stopTimer(task, now); err != nil {
+			return err
+		}
+		stopped++
+	}
+
+	if stopped == 0 && exceptID == 0 {
+		fmt.Println("No timer is running")
+	}
+
+	return nil
+}
+
+// stopTimer stops the running timer of a task and saves it
+func (a *App) stopTimer(task models.Task, now time.Time) error {
+	duration, err := task.StopTimer(now)
+	if err != nil {
+		return err
+	}
+
+	task.UpdatedAt = now
+	if err := a.storage.UpdateTask(task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	fmt.Printf("Timer stopped for task %d after %s\n", task.ID, models.FormatDuration(duration))
+	return nil
+}
+
+// handleLog handles the log command
+func (a *App) handleLog(args []string) error {
+	positional := positionalArgs(args)
+	if len(positional) < 2 {
+		fmt.Println("Error: Task ID and duration are required")
+		return nil
+	}
+
+	id, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	duration, err := time.ParseDuration(positional[1])
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+
+	task, err := a.storage.GetTaskByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	now := time.Now()
+	start := now.Add(-duration)
+
+	// Sessions logged for another day start at midnight of that day
+	if date, ok := parseArgs(args)["date"]; ok {
+		day, err := parseDate(date)
+		if err != nil {
+			return err
+		}
+		start = day
+	}
+
+	if err := task.LogTime(start, duration); err != nil {
+		return err
+	}
+
+	task.UpdatedAt = now
+	if err := a.storage.UpdateTask(task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	fmt.Printf("Logged %s on task %d (total %s)\n", models.FormatDuration(duration), task.ID, models.FormatDuration(task.TrackedTime(now)))
+	return nil
+}
+
+// handleTime handles the time command
+func (a *App) handleTime(args []string) error {
+	parsedArgs := parseArgs(args)
+
+	tasks, err := a.storage.GetTasks()
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	by := parsedArgs["by"]
+	switch by {
+	case "", "task", "label", "day":
+	default:
+		return fmt.Errorf("unknown grouping: %s (expected task, label or day)", by)
+	}
+
+	var since time.Time
+	if date, ok := parsedArgs["since"]; ok {
+		since, err = parseDate(date)
+		if err != nil {
+			return err
+		}
+	}
+
+	report := buildTimeReport(tasks, since, time.Now())
+	if len(report.byTask) == 0 {
+		fmt.Println("No time tracked")
+		return nil
+	}
+
+	if by == "" || by == "task" {
+		fmt.Println("Time by task:")
+		for _, task := range tasks {
+			if total, ok := report.byTask[task.ID]; ok {
+				fmt.Printf("  %d. %s: %s\n", task.ID, task.Title, models.FormatDuration(total))
+			}
+		}
+	}
+
+	if by == "" || by == "label" {
+		fmt.Println("Time by label:")
+		for _, label := range sortedKeys(report.byLabel) {
+			fmt.Printf("  %s: %s\n", label, models.FormatDuration(report.byLabel[label]))
+		}
+	}
+
+	if by == "" || by == "day" {
+		fmt.Println("Time by day:")
+		for _, day := range sortedKeys(report.byDay) {
+			fmt.Printf("  %s: %s\n", day, models.FormatDuration(report.byDay[day]))
+		}
+	}
+
+	fmt.Printf("Total: %s\n", models.FormatDuration(report.total))
+	return nil
+}
+
+// timeReport holds tracked time summed per task, label and day
+type timeReport struct {
+	byTask  map[int]time.Duration
+	byLabel map[string]time.Duration
+	byDay   map[string]time.Duration
+	total   time.Duration
+}
+
+// buildTimeReport sums the sessions started at or after since. Sessions are
+// attributed to the day they started.
+func buildTimeReport(tasks []models.Task, since, now time.Time) timeReport {
+	report := timeReport{
+		byTask:  make(map[int]time.Duration),
+		byLabel: make(map[string]time.Duration),
+		byDay:   make(map[string]time.Duration),
+	}
+
+	for _, task := range tasks {
+		for _, entry := range task.TimeEntries {
+			if entry.Start.Before(since) {
+				continue
+			}
+			duration := entry.Duration(now)
+			report.byTask[task.ID] += duration
+			report.byLabel[task.Label] += duration
+			report.byDay[entry.Start.Local().Format(models.DateLayout)] += duration
+			report.total += duration
+		}
+	}
+
+	return report
+}
+
+// sortedKeys returns the keys of a duration map in ascending order
+func sortedKeys(m map[string]time.Duration) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleStartStop(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	for _, title := range []string{"Task 1", "Task 2"} {
+		if _, err := app.storage.AddTask(models.NewTask(title, "test")); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	// Test starting a timer
+	if err := app.handleStart([]string{"1"}); err != nil {
+		t.Fatalf("Expected no error when starting timer, got %v", err)
+	}
+
+	task, _ := app.storage.GetTaskByID(1)
+	if !task.Running() {
+		t.Error("Expected timer to be running on task 1")
+	}
+
+	if task.Status != models.StatusInProgress {
+		t.Errorf("Expected status to be %s, got %s", models.StatusInProgress, task.Status)
+	}
+
+	// Test starting another timer stops the first one
+	if err := app.handleStart([]string{"2"}); err != nil {
+		t.Fatalf("Expected no error when starting second timer, got %v", err)
+	}
+
+	task, _ = app.storage.GetTaskByID(1)
+	if task.Running() {
+		t.Error("Expected timer on task 1 to be stopped")
+	}
+
+	// Test stopping without an ID
+	if err := app.handleStop([]string{}); err != nil {
+		t.Fatalf("Expected no error when stopping timer, got %v", err)
+	}
+
+	task, _ = app.storage.GetTaskByID(2)
+	if task.Running() {
+		t.Error("Expected timer on task 2 to be stopped")
+	}
+
+	// Test stopping when nothing is running
+	if err := app.handleStop([]string{"2"}); err != nil {
+		t.Errorf("Expected no error when stopping idle task, got %v", err)
+	}
+
+	if err := app.handleStop([]string{}); err != nil {
+		t.Errorf("Expected no error when no timer is running, got %v", err)
+	}
+
+	// Test invalid arguments
+	if err := app.handleStart([]string{"invalid"}); err == nil {
+		t.Error("Expected error when starting with invalid ID, got nil")
+	}
+
+	if err := app.handleStart([]string{"999"}); err == nil {
+		t.Error("Expected error when starting non-existent task, got nil")
+	}
+
+	if err := app.handleStart([]string{}); err != nil {
+		t.Errorf("Expected no error when starting with no ID, got %v", err)
+	}
+}
+
+func TestHandleLogAndTime(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	if _, err := app.storage.AddTask(models.NewTask("Task 1", "bug")); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	// Test logging time
+	if err := app.handleLog([]string{"1", "1h30m"}); err != nil {
+		t.Fatalf("Expected no error when logging time, got %v", err)
+	}
+
+	if err := app.handleLog([]string{"1", "45m", "--date", "2024-03-01"}); err != nil {
+		t.Fatalf("Expected no error when logging time on a date, got %v", err)
+	}
+
+	task, _ := app.storage.GetTaskByID(1)
+	if tracked := task.TrackedTime(time.Now()); tracked != 135*time.Minute {
+		t.Errorf("Expected 2h15m tracked, got %s", tracked)
+	}
+
+	// Test invalid log arguments
+	if err := app.handleLog([]string{"1", "soon"}); err == nil {
+		t.Error("Expected error for invalid duration, got nil")
+	}
+
+	if err := app.handleLog([]string{"1", "1h", "--date", "yesterday"}); err == nil {
+		t.Error("Expected error for invalid date, got nil")
+	}
+
+	if err := app.handleLog([]string{"1"}); err != nil {
+		t.Errorf("Expected no error with missing duration, got %v", err)
+	}
+
+	// Test the time report
+	for _, args := range [][]string{{}, {"--by", "label"}, {"--since", "2024-03-02"}} {
+		if err := app.handleTime(args); err != nil {
+			t.Errorf("Expected no error for time report %v, got %v", args, err)
+		}
+	}
+
+	if err := app.handleTime([]string{"--by", "week"}); err == nil {
+		t.Error("Expected error for unknown grouping, got nil")
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+
+	task1 := models.NewTask("Task 1", "bug")
+	task1.ID = 1
+	task1.LogTime(day, time.Hour)
+	task1.LogTime(day.AddDate(0, 0, 1), 30*time.Minute)
+
+	task2 := models.NewTask("Task 2", "bug")
+	task2.ID = 2
+	task2.LogTime(day, 15*time.Minute)
+
+	report := buildTimeReport([]models.Task{task1, task2}, time.Time{}, time.Now())
+
+	if report.byTask[1] != 90*time.Minute {
+		t.Errorf("Expected 1h30m for task 1, got %s", report.byTask[1])
+	}
+
+	if report.byLabel["bug"] != 105*time.Minute {
+		t.Errorf("Expected 1h45m for bug, got %s", report.byLabel["bug"])
+	}
+
+	if report.byDay["2024-03-01"] != 75*time.Minute {
+		t.Errorf("Expected 1h15m on 2024-03-01, got %s", report.byDay["2024-03-01"])
+	}
+
+	report = buildTimeReport([]models.Task{task1, task2}, day.AddDate(0, 0, 1), time.Now())
+	if report.total != 30*time.Minute {
+		t.Errorf("Expected 30m since the second day, got %s", report.total)
+	}
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// handleStatuses handles the statuses command
+func (a *App) handleStatuses(args []string) error {
+	workflow := a.workflow()
+
+	fmt.Println("Statuses:")
+	for _, def := range workflow.Statuses {
+		fmt.Printf("  %s (%s) -> %s\n", def.Name, def.Category, formatTransitions(workflow, def.Name))
+	}
+
+	return nil
+}
+
+// formatTransitions lists the statuses reachable from the given status
+func formatTransitions(workflow *models.Workflow, from models.Status) string {
+	var targets []string
+	for _, to := range workflow.Names() {
+		if to != from && workflow.CanTransition(from, to) {
+			targets = append(targets, string(to))
+		}
+	}
+
+	if len(targets) == 0 {
+		return "(none)"
+	}
+	return strings.Join(targets, ", ")
+}
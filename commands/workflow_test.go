@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/config"
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+func TestHandleUpdateWithWorkflow(t *testing.T) {
+	cfg := config.Default()
+	cfg.Workflow = &models.Workflow{
+		Statuses: []models.StatusDefinition{
+			{Name: models.StatusTodo, Category: models.CategoryOpen},
+			{Name: models.StatusInProgress, Category: models.CategoryActive},
+			{Name: "review", Category: models.CategoryActive},
+			{Name: models.StatusDone, Category: models.CategoryClosed},
+		},
+		Transitions: map[models.Status][]models.Status{
+			models.StatusTodo:       {models.StatusInProgress},
+			models.StatusInProgress: {"review"},
+			"review":                {models.StatusDone},
+		},
+	}
+
+	app := &App{
+		storage: storage.NewMockStorage(),
+		config:  cfg,
+	}
+
+	if err := app.handleAdd([]string{"Test Task"}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	steps := []struct {
+		status   string
+		expected models.Status
+	}{
+		{"done", models.StatusTodo},              // transition not allowed
+		{"in-progress", models.StatusInProgress}, // allowed
+		{"wontfix", models.StatusInProgress},     // unknown status
+		{"review", "review"},                     // allowed
+		{"done", models.StatusDone},              // allowed
+	}
+
+	for _, step := range steps {
+		if err := app.handleUpdate([]string{"1", "--status", step.status}); err != nil {
+			t.Fatalf("Expected no error when updating status to %s, got %v", step.status, err)
+		}
+
+		task, err := app.storage.GetTaskByID(1)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if task.Status != step.expected {
+			t.Errorf("After update to %s expected status %s, got %s", step.status, step.expected, task.Status)
+		}
+	}
+}
+
+func TestHandleStatuses(t *testing.T) {
+	app := &App{
+		storage: storage.NewMockStorage(),
+	}
+
+	if err := app.handleStatuses([]string{}); err != nil {
+		t.Errorf("Expected no error when listing statuses, got %v", err)
+	}
+}
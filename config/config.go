@@ -0,0 +1,412 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+	"github.com/mstgnz/cli-task-manager/storage"
+)
+
+const (
+	// AppName is the directory name used for configuration and data files
+	AppName = "cli-task-manager"
+
+	// LocalConfigFile is the per-repository override file discovered from the working directory
+	LocalConfigFile = ".cli-task-manager.json"
+
+	// ProjectDir is the directory holding a project-local task store
+	ProjectDir = ".issues"
+
+	// EnvPrefix is the prefix of environment variables overriding configuration values
+	EnvPrefix = "CLI_TASK_MANAGER_"
+)
+
+// Output styles supported by list-like commands
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// DefaultContext is the name of the implicit context using the global store
+const DefaultContext = "default"
+
+// Config holds the user configurable settings of the application
+type Config struct {
+	StoragePath       string                            `json:"storage_path,omitempty"`
+	StorageBackend    string                            `json:"storage_backend,omitempty"`
+	BackupCount       string                            `json:"backup_count,omitempty"`
+	BackupMaxAge      string                            `json:"backup_max_age,omitempty"`
+	EncryptionKeyFile string                            `json:"encryption_key_file,omitempty"`
+	KeyFiles          map[string]string                 `json:"key_files,omitempty"`
+	DefaultLabel      string                            `json:"default_label,omitempty"`
+	Output            string                            `json:"output,omitempty"`
+	CurrentContext    string                            `json:"current_context,omitempty"`
+	Contexts          map[string]Context                `json:"contexts,omitempty"`
+	Workflow          *models.Workflow                  `json:"workflow,omitempty"`
+	Fields            map[string]models.FieldDefinition `json:"fields,omitempty"`
+}
+
+// Context is a named task store that can be switched to
+type Context struct {
+	StoragePath string `json:"storage_path"`
+	Backend     string `json:"backend,omitempty"`
+}
+
+// key describes a single configuration key exposed through `config get/set`
+type key struct {
+	get      func(c *Config) string
+	set      func(c *Config, value string)
+	validate func(value string) error
+	// check validates the value against the rest of the effective
+	// configuration, which a single config file may not hold
+	check func(c *Config, value string) error
+}
+
+var keys = map[string]key{
+	"storage_path": {
+		get: func(c *Config) string { return c.StoragePath },
+		set: func(c *Config, value string) { c.StoragePath = value },
+	},
+	"storage_backend": {
+		get: func(c *Config) string { return c.StorageBackend },
+		set: func(c *Config, value string) { c.StorageBackend = value },
+		validate: func(value string) error {
+			for _, backend := range storage.Backends() {
+				if value == backend {
+					return nil
+				}
+			}
+			return fmt.Errorf("storage_backend must be one of %s", strings.Join(storage.Backends(), ", "))
+		},
+	},
+	"backup_count": {
+		get: func(c *Config) string { return c.BackupCount },
+		set: func(c *Config, value string) { c.BackupCount = value },
+		validate: func(value string) error {
+			if count, err := strconv.Atoi(value); err != nil || count < 0 {
+				return errors.New("backup_count must be a non-negative number")
+			}
+			return nil
+		},
+	},
+	"backup_max_age": {
+		get: func(c *Config) string { return c.BackupMaxAge },
+		set: func(c *Config, value string) { c.BackupMaxAge = value },
+		validate: func(value string) error {
+			_, err := parseAge(value)
+			return err
+		},
+	},
+	"encryption_key_file": {
+		get: func(c *Config) string { return c.EncryptionKeyFile },
+		set: func(c *Config, value string) { c.EncryptionKeyFile = value },
+	},
+	"default_label": {
+		get: func(c *Config) string { return c.DefaultLabel },
+		set: func(c *Config, value string) { c.DefaultLabel = value },
+		validate: func(value string) error {
+			if strings.TrimSpace(value) == "" {
+				return errors.New("default_label cannot be empty")
+			}
+			return nil
+		},
+	},
+	"current_context": {
+		get: func(c *Config) string { return c.CurrentContext },
+		set: func(c *Config, value string) { c.CurrentContext = value },
+		check: func(c *Config, value string) error {
+			if _, ok := c.Contexts[value]; !ok && value != DefaultContext {
+				return fmt.Errorf("unknown context: %s", value)
+			}
+			return nil
+		},
+	},
+	"output": {
+		get: func(c *Config) string { return c.Output },
+		set: func(c *Config, value string) { c.Output = value },
+		validate: func(value string) error {
+			if value != OutputText && value != OutputJSON {
+				return fmt.Errorf("output must be %q or %q", OutputText, OutputJSON)
+			}
+			return nil
+		},
+	},
+}
+
+// Default returns the built-in configuration
+func Default() *Config {
+	return &Config{
+		DefaultLabel: "task",
+		Output:       OutputText,
+		BackupCount:  "10",
+	}
+}
+
+// Keys returns the sorted list of supported configuration keys
+func Keys() []string {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the value of the given configuration key
+func (c *Config) Get(name string) (string, error) {
+	k, ok := keys[name]
+	if !ok {
+		return "", fmt.Errorf("unknown config key: %s", name)
+	}
+	return k.get(c), nil
+}
+
+// Set validates and stores the value of the given configuration key
+func (c *Config) Set(name, value string) error {
+	k, ok := keys[name]
+	if !ok {
+		return fmt.Errorf("unknown config key: %s", name)
+	}
+	if k.validate != nil {
+		if err := k.validate(value); err != nil {
+			return err
+		}
+	}
+	k.set(c, value)
+	return nil
+}
+
+// Check validates the value of the given configuration key against the
+// effective configuration c, e.g. that current_context names a context
+func (c *Config) Check(name, value string) error {
+	k, ok := keys[name]
+	if !ok {
+		return fmt.Errorf("unknown config key: %s", name)
+	}
+	if k.validate != nil {
+		if err := k.validate(value); err != nil {
+			return err
+		}
+	}
+	if k.check != nil {
+		return k.check(c, value)
+	}
+	return nil
+}
+
+// merge overlays every value set in src onto c. Empty values are not set,
+// so a later file cannot clear a key set by an earlier one, only override it.
+func (c *Config) merge(src *Config) {
+	for _, k := range keys {
+		if value := k.get(src); value != "" {
+			k.set(c, value)
+		}
+	}
+
+	if src.Workflow != nil {
+		c.Workflow = src.Workflow
+	}
+
+	for name, def := range src.Fields {
+		if c.Fields == nil {
+			c.Fields = make(map[string]models.FieldDefinition)
+		}
+		c.Fields[name] = def
+	}
+
+	for name, ctx := range src.Contexts {
+		if c.Contexts == nil {
+			c.Contexts = make(map[string]Context)
+		}
+		c.Contexts[name] = ctx
+	}
+
+	for storePath, keyFile := range src.KeyFiles {
+		if c.KeyFiles == nil {
+			c.KeyFiles = make(map[string]string)
+		}
+		c.KeyFiles[storePath] = keyFile
+	}
+}
+
+// ContextNames returns the sorted names of the configured contexts
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveContext returns the name of the current context, or DefaultContext
+func (c *Config) ActiveContext() string {
+	if c.CurrentContext == "" {
+		return DefaultContext
+	}
+	return c.CurrentContext
+}
+
+// Load builds the effective configuration for the current working directory
+func Load() (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return LoadFrom(cwd)
+}
+
+// LoadFrom builds the effective configuration by layering, in order, the
+// built-in defaults, the user config file, the nearest local override file
+// found by walking up from dir and finally environment variables
+func LoadFrom(dir string) (*Config, error) {
+	cfg := Default()
+
+	userPath, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{userPath}
+	if localPath := FindLocalConfig(dir); localPath != "" {
+		paths = append(paths, localPath)
+	}
+
+	for _, path := range paths {
+		fileCfg, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg.merge(fileCfg)
+	}
+
+	if cfg.Workflow != nil {
+		if err := cfg.Workflow.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid workflow: %w", err)
+		}
+	}
+
+	for name, def := range cfg.Fields {
+		if err := def.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid field %s: %w", name, err)
+		}
+	}
+
+	for _, name := range Keys() {
+		value, ok := os.LookupEnv(EnvPrefix + strings.ToUpper(name))
+		if !ok {
+			continue
+		}
+		if err := cfg.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid %s%s: %w", EnvPrefix, strings.ToUpper(name), err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// LoadFile reads a single config file, returning an empty config if it does not exist
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes the config to the given path, creating parent directories as needed
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveWorkflow returns the configured workflow or the default one
+func (c *Config) ResolveWorkflow() *models.Workflow {
+	if c.Workflow == nil {
+		return models.DefaultWorkflow()
+	}
+	return c.Workflow
+}
+
+// ResolveStoragePath returns the configured storage path with "~" expanded,
+// falling back to the default file of the storage backend in the user's
+// home directory
+func (c *Config) ResolveStoragePath() (string, error) {
+	if c.StoragePath == "" {
+		dir, err := DataDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, storage.FileName(c.StorageBackend)), nil
+	}
+	return ExpandPath(c.StoragePath)
+}
+
+// BackupPolicy returns the configured rotation of the automatic backups
+func (c *Config) BackupPolicy() (storage.BackupPolicy, error) {
+	var policy storage.BackupPolicy
+
+	if c.BackupCount != "" {
+		count, err := strconv.Atoi(c.BackupCount)
+		if err != nil || count < 0 {
+			return policy, errors.New("backup_count must be a non-negative number")
+		}
+		policy.Count = count
+	}
+
+	age, err := parseAge(c.BackupMaxAge)
+	if err != nil {
+		return policy, err
+	}
+	policy.MaxAge = age
+
+	return policy, nil
+}
+
+// parseAge parses a maximum age given as a duration such as "72h" or as a
+// number of days such as "30d". An empty value means no limit.
+func parseAge(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if age, err := time.ParseDuration(value); err == nil && age >= 0 {
+		return age, nil
+	}
+
+	return 0, fmt.Errorf("backup_max_age must be a duration like 72h or a number of days like 30d, got %q", value)
+}
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+
+	if cfg.DefaultLabel != "task" {
+		t.Errorf("Expected default label to be 'task', got %s", cfg.DefaultLabel)
+	}
+
+	if cfg.Output != OutputText {
+		t.Errorf("Expected output to be %s, got %s", OutputText, cfg.Output)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	cfg := Default()
+
+	if err := cfg.Set("default_label", "bug"); err != nil {
+		t.Fatalf("Failed to set default_label: %v", err)
+	}
+
+	value, err := cfg.Get("default_label")
+	if err != nil {
+		t.Fatalf("Failed to get default_label: %v", err)
+	}
+
+	if value != "bug" {
+		t.Errorf("Expected default_label to be 'bug', got %s", value)
+	}
+
+	if err := cfg.Set("output", "xml"); err == nil {
+		t.Error("Expected error when setting invalid output, got nil")
+	}
+
+	if err := cfg.Set("storage_backend", "yaml"); err != nil {
+		t.Fatalf("Failed to set storage_backend: %v", err)
+	}
+
+	if cfg.StorageBackend != "yaml" {
+		t.Errorf("Expected storage backend to be 'yaml', got %s", cfg.StorageBackend)
+	}
+
+	if err := cfg.Set("storage_backend", "xml"); err == nil {
+		t.Error("Expected error when setting unknown storage backend, got nil")
+	}
+
+	if err := cfg.Set("unknown", "value"); err == nil {
+		t.Error("Expected error when setting unknown key, got nil")
+	}
+
+	if _, err := cfg.Get("unknown"); err == nil {
+		t.Error("Expected error when getting unknown key, got nil")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cfg := Default()
+	cfg.Contexts = map[string]Context{"work": {StoragePath: "/tmp/work.json"}}
+
+	for _, name := range []string{"work", DefaultContext} {
+		if err := cfg.Check("current_context", name); err != nil {
+			t.Errorf("Expected context %s to be valid, got %v", name, err)
+		}
+	}
+
+	if err := cfg.Check("current_context", "home"); err == nil {
+		t.Error("Expected error for an unknown context, got nil")
+	}
+
+	if err := cfg.Check("output", "xml"); err == nil {
+		t.Error("Expected error when checking invalid output, got nil")
+	}
+}
+
+func TestLoadFrom(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "xdg"))
+	clearEnv(t)
+
+	// User config sets the label and storage path
+	userCfg := &Config{DefaultLabel: "feature", StoragePath: "/tmp/user.json"}
+	if err := userCfg.Save(filepath.Join(tempDir, "xdg", AppName, "config.json")); err != nil {
+		t.Fatalf("Failed to save user config: %v", err)
+	}
+
+	// Local config in the repository root overrides the storage path
+	repoDir := filepath.Join(tempDir, "repo")
+	nestedDir := filepath.Join(repoDir, "a", "b")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+	localCfg := &Config{StoragePath: "/tmp/local.json"}
+	if err := localCfg.Save(filepath.Join(repoDir, LocalConfigFile)); err != nil {
+		t.Fatalf("Failed to save local config: %v", err)
+	}
+
+	// Contexts from every file are combined
+	userCfg.Contexts = map[string]Context{"work": {StoragePath: "/tmp/work.json"}}
+	if err := userCfg.Save(filepath.Join(tempDir, "xdg", AppName, "config.json")); err != nil {
+		t.Fatalf("Failed to save user config: %v", err)
+	}
+
+	// Environment overrides the label
+	t.Setenv(EnvPrefix+"DEFAULT_LABEL", "bug")
+
+	cfg, err := LoadFrom(nestedDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.StoragePath != "/tmp/local.json" {
+		t.Errorf("Expected storage path from local config, got %s", cfg.StoragePath)
+	}
+
+	if cfg.DefaultLabel != "bug" {
+		t.Errorf("Expected default label from environment, got %s", cfg.DefaultLabel)
+	}
+
+	if cfg.Output != OutputText {
+		t.Errorf("Expected default output, got %s", cfg.Output)
+	}
+
+	if cfg.Contexts["work"].StoragePath != "/tmp/work.json" {
+		t.Errorf("Expected work context from user config, got %v", cfg.Contexts)
+	}
+
+	if cfg.ActiveContext() != DefaultContext {
+		t.Errorf("Expected default context, got %s", cfg.ActiveContext())
+	}
+
+	// Invalid environment values are rejected
+	t.Setenv(EnvPrefix+"OUTPUT", "xml")
+	if _, err := LoadFrom(nestedDir); err == nil {
+		t.Error("Expected error for invalid output environment variable, got nil")
+	}
+}
+
+// clearEnv removes configuration overrides inherited from the environment
+func clearEnv(t *testing.T) {
+	for _, name := range Keys() {
+		envName := EnvPrefix + strings.ToUpper(name)
+		t.Setenv(envName, "")
+		os.Unsetenv(envName)
+	}
+}
+
+func TestLoadFromInvalidWorkflow(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-workflow-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	clearEnv(t)
+
+	userCfg := &Config{Workflow: &models.Workflow{}}
+	if err := userCfg.Save(filepath.Join(tempDir, AppName, "config.json")); err != nil {
+		t.Fatalf("Failed to save user config: %v", err)
+	}
+
+	if _, err := LoadFrom(tempDir); err == nil {
+		t.Error("Expected error for invalid workflow, got nil")
+	}
+
+	if Default().ResolveWorkflow().InitialStatus() != models.StatusTodo {
+		t.Error("Expected default workflow when none is configured")
+	}
+}
+
+func TestBackupPolicy(t *testing.T) {
+	cfg := Default()
+
+	policy, err := cfg.BackupPolicy()
+	if err != nil {
+		t.Fatalf("Failed to get default backup policy: %v", err)
+	}
+
+	if policy.Count != 10 || policy.MaxAge != 0 {
+		t.Errorf("Expected 10 backups without age limit by default, got %d and %s", policy.Count, policy.MaxAge)
+	}
+
+	if err := cfg.Set("backup_count", "0"); err != nil {
+		t.Fatalf("Failed to set backup_count: %v", err)
+	}
+
+	if err := cfg.Set("backup_max_age", "30d"); err != nil {
+		t.Fatalf("Failed to set backup_max_age: %v", err)
+	}
+
+	policy, err = cfg.BackupPolicy()
+	if err != nil {
+		t.Fatalf("Failed to get backup policy: %v", err)
+	}
+
+	if policy.Count != 0 || policy.MaxAge != 30*24*time.Hour {
+		t.Errorf("Expected 0 backups kept for 30 days, got %d and %s", policy.Count, policy.MaxAge)
+	}
+
+	if err := cfg.Set("backup_max_age", "72h"); err != nil {
+		t.Errorf("Expected no error when setting backup_max_age to 72h, got %v", err)
+	}
+
+	for _, value := range []string{"-1", "many"} {
+		if err := cfg.Set("backup_count", value); err == nil {
+			t.Errorf("Expected error when setting backup_count to %q, got nil", value)
+		}
+	}
+
+	for _, value := range []string{"soon", "-2d", "-1h"} {
+		if err := cfg.Set("backup_max_age", value); err == nil {
+			t.Errorf("Expected error when setting backup_max_age to %q, got nil", value)
+		}
+	}
+}
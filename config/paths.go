@@ -0,0 +1,130 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UserConfigDir returns the directory holding the user config file,
+// honouring $XDG_CONFIG_HOME when it is set
+func UserConfigDir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, AppName), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, ".config", AppName), nil
+}
+
+// UserConfigPath returns the path of the user config file
+func UserConfigPath() (string, error) {
+	dir, err := UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// DataDir returns the directory holding the global task store
+func DataDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, "."+AppName), nil
+}
+
+// BackupDir returns the directory holding the backups of the task store at
+// storePath. Backups live under the data directory rather than next to the
+// store, so that project stores do not fill their repository with them, in a
+// directory named after the store and a hash of its absolute path.
+func BackupDir(storePath string) (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(storePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", storePath, err)
+	}
+
+	base := filepath.Base(absPath)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	sum := sha256.Sum256([]byte(absPath))
+	return filepath.Join(dataDir, "backups", stem+"-"+hex.EncodeToString(sum[:6])), nil
+}
+
+// FindProjectStore walks up from dir and returns the path of the tasks file
+// inside the nearest project store directory, or an empty string if there is none
+func FindProjectStore(dir string) string {
+	projectDir := findUp(dir, ProjectDir, isDir)
+	if projectDir == "" {
+		return ""
+	}
+	return filepath.Join(projectDir, "tasks.json")
+}
+
+// FindRepoRoot walks up from dir and returns the nearest directory containing
+// a .git entry, or an empty string if dir is not inside a repository
+func FindRepoRoot(dir string) string {
+	// Worktrees and submodules use a .git file instead of a directory
+	gitPath := findUp(dir, ".git", func(os.FileInfo) bool { return true })
+	if gitPath == "" {
+		return ""
+	}
+	return filepath.Dir(gitPath)
+}
+
+// FindLocalConfig walks up from dir and returns the path of the nearest
+// local override file, or an empty string if there is none
+func FindLocalConfig(dir string) string {
+	return findUp(dir, LocalConfigFile, isFile)
+}
+
+func isDir(info os.FileInfo) bool  { return info.IsDir() }
+func isFile(info os.FileInfo) bool { return !info.IsDir() }
+
+// findUp walks up from dir looking for an entry with the given name
+// accepted by match
+func findUp(dir, name string, match func(os.FileInfo) bool) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && match(info) {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ExpandPath expands a leading "~" to the user's home directory
+func ExpandPath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~")), nil
+}
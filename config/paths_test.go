@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindLocalConfig(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-find-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if path := FindLocalConfig(tempDir); path != "" {
+		t.Errorf("Expected no local config, got %s", path)
+	}
+
+	expected := filepath.Join(tempDir, LocalConfigFile)
+	if err := os.WriteFile(expected, []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write local config: %v", err)
+	}
+
+	nestedDir := filepath.Join(tempDir, "nested")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	if path := FindLocalConfig(nestedDir); path != expected {
+		t.Errorf("Expected local config %s, got %s", expected, path)
+	}
+}
+
+func TestBackupDir(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-backup-dir-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	t.Setenv("HOME", tempDir)
+
+	first, err := BackupDir("/work/api/.issues/tasks.json")
+	if err != nil {
+		t.Fatalf("Failed to get backup directory: %v", err)
+	}
+	second, err := BackupDir("/work/web/.issues/tasks.json")
+	if err != nil {
+		t.Fatalf("Failed to get backup directory: %v", err)
+	}
+
+	dataDir, _ := DataDir()
+	if !strings.HasPrefix(first, filepath.Join(dataDir, "backups", "tasks-")) {
+		t.Errorf("Expected backups under the data directory, got %s", first)
+	}
+	if first == second {
+		t.Errorf("Expected stores with the same file name to get their own backup directory, got %s", first)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("No home directory available")
+	}
+
+	path, err := ExpandPath("~/tasks.json")
+	if err != nil {
+		t.Fatalf("Failed to expand path: %v", err)
+	}
+
+	if path != filepath.Join(homeDir, "tasks.json") {
+		t.Errorf("Expected path in home directory, got %s", path)
+	}
+
+	path, err = ExpandPath("/tmp/tasks.json")
+	if err != nil {
+		t.Fatalf("Failed to expand path: %v", err)
+	}
+
+	if path != "/tmp/tasks.json" {
+		t.Errorf("Expected path to be unchanged, got %s", path)
+	}
+}
+
+func TestFindProjectStore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-project-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	nestedDir := filepath.Join(tempDir, "src", "pkg")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	if path := FindProjectStore(nestedDir); path != "" {
+		t.Errorf("Expected no project store, got %s", path)
+	}
+
+	if err := os.MkdirAll(filepath.Join(tempDir, ProjectDir), 0755); err != nil {
+		t.Fatalf("Failed to create project directory: %v", err)
+	}
+
+	expected := filepath.Join(tempDir, ProjectDir, "tasks.json")
+	if path := FindProjectStore(nestedDir); path != expected {
+		t.Errorf("Expected project store %s, got %s", expected, path)
+	}
+}
+
+func TestFindRepoRoot(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-repo-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	nestedDir := filepath.Join(tempDir, "src")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	// A .git file, as used by worktrees, marks the repository root
+	if err := os.WriteFile(filepath.Join(tempDir, ".git"), []byte("gitdir: elsewhere"), 0644); err != nil {
+		t.Fatalf("Failed to write .git file: %v", err)
+	}
+
+	if root := FindRepoRoot(nestedDir); root != tempDir {
+		t.Errorf("Expected repository root %s, got %s", tempDir, root)
+	}
+}
@@ -0,0 +1,279 @@
+package formats
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// csvFieldPrefix marks columns holding custom fields
+const csvFieldPrefix = "field:"
+
+// csvColumns lists the task columns in the order they are exported
+var csvColumns = []string{"id", "title", "description", "status", "label", "due", "estimate", "milestone", "recurrence", "created_at", "updated_at"}
+
+// CSV reads and writes tasks as comma-separated values with a header row
+type CSV struct {
+	workflow *models.Workflow
+	fields   map[string]models.FieldDefinition
+	columns  map[string]string
+}
+
+// NewCSV creates a CSV format. Columns of foreign spreadsheets are renamed
+// according to opts.Columns before being matched against task columns.
+func NewCSV(opts Options) *CSV {
+	columns := make(map[string]string, len(opts.Columns))
+	for from, to := range opts.Columns {
+		columns[normalizeColumn(from)] = normalizeColumn(to)
+	}
+	return &CSV{workflow: opts.Workflow, fields: opts.Fields, columns: columns}
+}
+
+// Encode writes a header row followed by one row per task. Custom fields are
+// written to "field:<name>" columns.
+func (f *CSV) Encode(w io.Writer, tasks []models.Task) error {
+	fieldNames := customFieldNames(tasks)
+
+	header := append([]string{}, csvColumns...)
+	for _, name := range fieldNames {
+		header = append(header, csvFieldPrefix+name)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, task := range tasks {
+		record := []string{
+			strconv.Itoa(task.ID),
+			task.Title,
+			task.Description,
+			string(task.Status),
+			task.Label,
+			formatOptionalDate(task.Due),
+			formatEstimate(task.Estimate),
+			task.Milestone,
+			task.Recurrence,
+			task.CreatedAt.Format(time.RFC3339),
+			task.UpdatedAt.Format(time.RFC3339),
+		}
+		for _, name := range fieldNames {
+			record = append(record, task.Field(name))
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// Decode reads tasks from CSV with a header row. Unknown columns are ignored.
+// Rows failing validation are skipped and reported through RowErrors.
+func (f *CSV) Decode(r io.Reader) ([]models.Task, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	hasTitle := false
+	for i, name := range header {
+		column := normalizeColumn(name)
+		if mapped, ok := f.columns[column]; ok {
+			column = mapped
+		}
+		columns[i] = column
+		hasTitle = hasTitle || column == "title"
+	}
+	if !hasTitle {
+		return nil, errors.New("no title column (map one with --map <column>=title)")
+	}
+
+	var (
+		tasks     []models.Task
+		rowErrors RowErrors
+	)
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		// Header row is row 1
+		row := len(tasks) + len(rowErrors) + 2
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			rowErrors = append(rowErrors, RowError{Row: row, Err: parseErr.Err})
+			continue
+		}
+
+		task, err := f.decodeRecord(columns, record)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(rowErrors) > 0 {
+		return tasks, rowErrors
+	}
+	return tasks, nil
+}
+
+// decodeRecord validates a record and converts it into a task
+func (f *CSV) decodeRecord(columns, record []string) (models.Task, error) {
+	now := time.Now()
+	task := models.Task{Status: f.workflow.InitialStatus(), CreatedAt: now, UpdatedAt: now}
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if i >= len(columns) || value == "" {
+			continue
+		}
+
+		if err := f.setColumn(&task, columns[i], value); err != nil {
+			return models.Task{}, fmt.Errorf("%s: %w", columns[i], err)
+		}
+	}
+
+	if task.Title == "" {
+		return models.Task{}, errors.New("title is empty")
+	}
+
+	return task, nil
+}
+
+// setColumn stores a non-empty column value on the task
+func (f *CSV) setColumn(task *models.Task, column, value string) error {
+	switch column {
+	case "title":
+		task.Title = value
+	case "description":
+		task.Description = value
+	case "status":
+		if !f.workflow.IsValid(models.Status(value)) {
+			return fmt.Errorf("unknown status %q", value)
+		}
+		task.Status = models.Status(value)
+	case "label":
+		task.Label = value
+	case "due":
+		due, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+		}
+		task.Due = &due
+	case "estimate":
+		estimate, err := models.ParseEstimate(value)
+		if err != nil {
+			return err
+		}
+		task.Estimate = &estimate
+	case "milestone":
+		task.Milestone = value
+	case "recurrence":
+		if _, err := models.ParseRecurrence(value); err != nil {
+			return err
+		}
+		task.Recurrence = value
+	case "created_at", "updated_at":
+		at, err := parseCSVTime(value)
+		if err != nil {
+			return err
+		}
+		if column == "created_at" {
+			task.CreatedAt = at
+		} else {
+			task.UpdatedAt = at
+		}
+	default:
+		name, ok := strings.CutPrefix(column, csvFieldPrefix)
+		if !ok {
+			// Columns such as id or foreign spreadsheet columns are ignored
+			return nil
+		}
+		if def, ok := f.fields[name]; ok {
+			normalized, err := def.Normalize(value)
+			if err != nil {
+				return err
+			}
+			value = normalized
+		}
+		task.SetField(name, value)
+	}
+
+	return nil
+}
+
+// normalizeColumn lower-cases a column name and replaces spaces with underscores
+func normalizeColumn(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+}
+
+// parseCSVTime parses an RFC 3339 timestamp or a YYYY-MM-DD date
+func parseCSVTime(value string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	at, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DD", value)
+	}
+	return at, nil
+}
+
+// customFieldNames returns the sorted names of the custom fields set on any task
+func customFieldNames(tasks []models.Task) []string {
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		for name := range task.Fields {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatOptionalDate formats a date, returning an empty string for nil
+func formatOptionalDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(models.DateLayout)
+}
+
+// formatEstimate formats an estimate, returning an empty string for nil
+func formatEstimate(e *models.Estimate) string {
+	if e == nil {
+		return ""
+	}
+	return e.String()
+}
@@ -0,0 +1,125 @@
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	format := NewCSV(Options{Workflow: models.DefaultWorkflow()})
+
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+	estimate := models.Estimate{Points: 3}
+
+	task := models.Task{
+		ID:          7,
+		Title:       "Export, with \"quotes\"",
+		Description: "Two\nlines",
+		Status:      models.StatusInProgress,
+		Label:       "feature",
+		Due:         &due,
+		Estimate:    &estimate,
+		Milestone:   "v1.0",
+		Recurrence:  "weekly:mon",
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+	task.SetField("customer", "Acme")
+
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, []models.Task{task, {ID: 8, Title: "Plain", Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created}}); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+
+	if header := strings.SplitN(buf.String(), "\n", 2)[0]; !strings.HasSuffix(header, ",field:customer") {
+		t.Errorf("Expected a custom field column, got header %q", header)
+	}
+
+	tasks, err := format.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode: unexpected error %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	got := tasks[0]
+	if got.Title != task.Title || got.Description != task.Description || got.Status != task.Status || got.Label != task.Label {
+		t.Errorf("Expected text columns to round trip, got %+v", got)
+	}
+	if got.Due == nil || !got.Due.Equal(due) {
+		t.Errorf("Expected due %s, got %v", due, got.Due)
+	}
+	if got.Estimate == nil || *got.Estimate != estimate {
+		t.Errorf("Expected estimate %s, got %v", estimate, got.Estimate)
+	}
+	if got.Milestone != "v1.0" || got.Recurrence != "weekly:mon" {
+		t.Errorf("Expected milestone and recurrence to round trip, got %+v", got)
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("Expected created %s, got %s", created, got.CreatedAt)
+	}
+	if got.Field("customer") != "Acme" {
+		t.Errorf("Expected customer Acme, got %q", got.Field("customer"))
+	}
+	if got.ID != 0 {
+		t.Errorf("Expected no ID on import, got %d", got.ID)
+	}
+}
+
+func TestCSVDecodeWithMapping(t *testing.T) {
+	format := NewCSV(Options{
+		Workflow: models.DefaultWorkflow(),
+		Fields:   map[string]models.FieldDefinition{"points": {Type: models.FieldNumber}},
+		Columns:  map[string]string{"Summary": "title", "State": "status", "Story Points": "field:points"},
+	})
+
+	input := strings.Join([]string{
+		"Summary,State,Story Points,Owner",
+		"Good row,in-progress,3.0,ann",
+		"Unknown status,blocked,1,bob",
+		",to-do,1,carl",
+		"Bad points,to-do,many,dan",
+		"Defaults,,,erin",
+	}, "\n")
+
+	tasks, err := format.Decode(strings.NewReader(input))
+
+	var rowErrors RowErrors
+	if !errors.As(err, &rowErrors) {
+		t.Fatalf("Expected RowErrors, got %v", err)
+	}
+
+	if len(rowErrors) != 3 || rowErrors[0].Row != 3 || rowErrors[1].Row != 4 || rowErrors[2].Row != 5 {
+		t.Errorf("Expected rows 3, 4 and 5 to fail, got %v", rowErrors)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 valid tasks, got %d", len(tasks))
+	}
+
+	if tasks[0].Status != models.StatusInProgress || tasks[0].Field("points") != "3" {
+		t.Errorf("Expected mapped status and normalized points, got %+v", tasks[0])
+	}
+
+	if tasks[1].Status != models.StatusTodo || tasks[1].Label != "" {
+		t.Errorf("Expected initial status and no label, got %+v", tasks[1])
+	}
+}
+
+func TestCSVDecodeErrors(t *testing.T) {
+	format := NewCSV(Options{Workflow: models.DefaultWorkflow()})
+
+	for _, input := range []string{"", "name,status\nTask,to-do\n"} {
+		if _, err := format.Decode(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %q, got nil", input)
+		}
+	}
+}
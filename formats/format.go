@@ -0,0 +1,112 @@
+package formats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Supported import and export formats
+const (
+	FormatICS         = "ics"
+	FormatCSV         = "csv"
+	FormatGitHub      = "github"
+	FormatTodoTxt     = "todotxt"
+	FormatTaskwarrior = "taskwarrior"
+	FormatMarkdown    = "markdown"
+)
+
+// Format converts tasks to and from an external file format
+type Format interface {
+	// Encode writes the tasks to w
+	Encode(w io.Writer, tasks []models.Task) error
+
+	// Decode reads tasks from r. Decoded tasks have no ID and an empty label
+	// when the source does not provide one.
+	Decode(r io.Reader) ([]models.Task, error)
+}
+
+// Names returns the names of the supported formats
+func Names() []string {
+	return []string{FormatICS, FormatCSV, FormatGitHub, FormatTodoTxt, FormatTaskwarrior, FormatMarkdown}
+}
+
+// Keyed is implemented by formats whose tasks carry an identity from their
+// source, so that importing the same data again updates tasks instead of
+// duplicating them
+type Keyed interface {
+	// KeyField returns the custom field holding the identity
+	KeyField() string
+}
+
+// Options configure how a format reads and writes tasks
+type Options struct {
+	// Workflow maps the statuses of the format onto task statuses
+	Workflow *models.Workflow
+
+	// Fields declares the custom fields that imported values are validated against
+	Fields map[string]models.FieldDefinition
+
+	// Columns maps foreign column names onto task columns
+	Columns map[string]string
+
+	// GroupBy selects how exported tasks are grouped, by status or label
+	GroupBy string
+}
+
+// RowError reports a record that failed validation
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// RowErrors is returned by Decode alongside the valid tasks when some records
+// failed validation
+type RowErrors []RowError
+
+func (e RowErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d rows failed validation", len(e))
+}
+
+// New creates the format with the given name
+func New(name string, opts Options) (Format, error) {
+	switch name {
+	case FormatICS:
+		return NewICS(opts.Workflow), nil
+	case FormatCSV:
+		return NewCSV(opts), nil
+	case FormatGitHub:
+		return NewGitHub(opts.Workflow), nil
+	case FormatTodoTxt:
+		return NewTodoTxt(opts.Workflow), nil
+	case FormatTaskwarrior:
+		return NewTaskwarrior(opts.Workflow), nil
+	case FormatMarkdown:
+		return NewMarkdown(opts.Workflow, opts.GroupBy)
+	default:
+		return nil, fmt.Errorf("unknown format: %s", name)
+	}
+}
+
+// statusFor returns the first status of the workflow in the given category,
+// falling back to the initial status
+func statusFor(workflow *models.Workflow, category models.Category) models.Status {
+	if status, ok := workflow.FirstInCategory(category); ok {
+		return status
+	}
+	return workflow.InitialStatus()
+}
+
+// singleWord replaces whitespace with underscores so that a value stays a single word
+func singleWord(value string) string {
+	return strings.Join(strings.Fields(value), "_")
+}
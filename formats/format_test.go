@@ -0,0 +1,19 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		if _, err := New(name, Options{Workflow: models.DefaultWorkflow()}); err != nil {
+			t.Errorf("New(%q): unexpected error %v", name, err)
+		}
+	}
+
+	if _, err := New("xml", Options{Workflow: models.DefaultWorkflow()}); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+}
@@ -0,0 +1,157 @@
+package formats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Custom fields used by the GitHub format
+const (
+	// GitHubIssueField records the number of an imported issue
+	GitHubIssueField = "github_issue"
+
+	// LabelsField holds the labels of an issue after the first one, which
+	// becomes the task's label, separated by commas
+	LabelsField = "labels"
+)
+
+// GitHub reads a JSON dump of GitHub issues as returned by the REST API or by
+// `gh issue list --json`
+type GitHub struct {
+	workflow *models.Workflow
+}
+
+// githubIssue holds the issue attributes used by the importer. The REST API
+// uses snake_case keys while the gh CLI uses camelCase ones.
+type githubIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"`
+	Labels      []githubLabel   `json:"labels"`
+	Milestone   *githubTitle    `json:"milestone"`
+	CreatedAt   *time.Time      `json:"created_at"`
+	UpdatedAt   *time.Time      `json:"updated_at"`
+	ClosedAt    *time.Time      `json:"closed_at"`
+	CreatedAtGH *time.Time      `json:"createdAt"`
+	UpdatedAtGH *time.Time      `json:"updatedAt"`
+	ClosedAtGH  *time.Time      `json:"closedAt"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+type githubTitle struct {
+	Title string `json:"title"`
+}
+
+// NewGitHub creates a GitHub issues importer using the given workflow
+func NewGitHub(workflow *models.Workflow) *GitHub {
+	return &GitHub{workflow: workflow}
+}
+
+// KeyField returns the field identifying imported issues
+func (f *GitHub) KeyField() string {
+	return GitHubIssueField
+}
+
+// Encode is not supported, GitHub issues can only be imported
+func (f *GitHub) Encode(w io.Writer, tasks []models.Task) error {
+	return fmt.Errorf("the github format can only be imported: %w", errors.ErrUnsupported)
+}
+
+// Decode reads a JSON array of issues, skipping pull requests
+func (f *GitHub) Decode(r io.Reader) ([]models.Task, error) {
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub issues: %w", err)
+	}
+
+	var (
+		tasks     []models.Task
+		rowErrors RowErrors
+	)
+
+	for i, issue := range issues {
+		if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+			continue
+		}
+
+		task, err := f.decodeIssue(issue)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: i + 1, Err: err})
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(rowErrors) > 0 {
+		return tasks, rowErrors
+	}
+	return tasks, nil
+}
+
+// decodeIssue converts an issue into a task
+func (f *GitHub) decodeIssue(issue githubIssue) (models.Task, error) {
+	if issue.Number <= 0 {
+		return models.Task{}, errors.New("issue has no number")
+	}
+	if strings.TrimSpace(issue.Title) == "" {
+		return models.Task{}, fmt.Errorf("issue #%d has no title", issue.Number)
+	}
+
+	now := time.Now()
+	created := firstTime(now, issue.CreatedAt, issue.CreatedAtGH)
+
+	task := models.Task{
+		Title:       issue.Title,
+		Description: issue.Body,
+		Status:      statusFor(f.workflow, models.CategoryOpen),
+		CreatedAt:   created,
+		UpdatedAt:   firstTime(created, issue.UpdatedAt, issue.UpdatedAtGH),
+	}
+	task.SetField(GitHubIssueField, strconv.Itoa(issue.Number))
+
+	if len(issue.Labels) > 0 {
+		task.Label = issue.Labels[0].Name
+	}
+	if len(issue.Labels) > 1 {
+		names := make([]string, 0, len(issue.Labels)-1)
+		for _, label := range issue.Labels[1:] {
+			names = append(names, label.Name)
+		}
+		task.SetField(LabelsField, strings.Join(names, ","))
+	}
+	if issue.Milestone != nil {
+		task.Milestone = issue.Milestone.Title
+	}
+
+	switch strings.ToLower(issue.State) {
+	case "", "open":
+	case "closed":
+		task.SetStatus(statusFor(f.workflow, models.CategoryClosed), firstTime(task.UpdatedAt, issue.ClosedAt, issue.ClosedAtGH))
+	default:
+		return models.Task{}, fmt.Errorf("issue #%d has unknown state %q", issue.Number, issue.State)
+	}
+
+	return task, nil
+}
+
+// firstTime returns the first non-nil time, or fallback
+func firstTime(fallback time.Time, times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil {
+			return *t
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,77 @@
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestGitHubDecode(t *testing.T) {
+	input := `[
+		{"number": 12, "title": "Login broken", "body": "Steps", "state": "OPEN",
+		 "labels": [{"name": "bug"}, {"name": "p1"}, {"name": "needs triage"}],
+		 "createdAt": "2024-03-01T10:00:00Z", "updatedAt": "2024-03-02T10:00:00Z"},
+		{"number": 13, "title": "A pull request", "state": "open", "pull_request": {"url": "https://example.com"}},
+		{"number": 14, "title": "Add export", "state": "closed", "milestone": {"title": "v1.0"},
+		 "created_at": "2024-03-01T10:00:00Z", "closed_at": "2024-03-05T10:00:00Z"},
+		{"number": 15, "title": "", "state": "open"},
+		{"number": 16, "title": "Odd state", "state": "merged"}
+	]`
+
+	workflow := models.DefaultWorkflow()
+	tasks, err := NewGitHub(workflow).Decode(strings.NewReader(input))
+
+	var rowErrors RowErrors
+	if !errors.As(err, &rowErrors) || len(rowErrors) != 2 {
+		t.Fatalf("Expected 2 row errors, got %v", err)
+	}
+	if rowErrors[0].Row != 4 || rowErrors[1].Row != 5 {
+		t.Errorf("Expected issues 4 and 5 to fail, got %v", rowErrors)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	open := tasks[0]
+	if open.Title != "Login broken" || open.Description != "Steps" || open.Label != "bug" {
+		t.Errorf("Expected issue attributes to be mapped, got %+v", open)
+	}
+	if open.Field(LabelsField) != "p1,needs triage" {
+		t.Errorf("Expected the other labels to be kept, got %q", open.Field(LabelsField))
+	}
+	if open.Status != models.StatusTodo {
+		t.Errorf("Expected open issue to be to-do, got %s", open.Status)
+	}
+	if open.Field(GitHubIssueField) != "12" {
+		t.Errorf("Expected issue number 12 to be recorded, got %q", open.Field(GitHubIssueField))
+	}
+	if expected := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC); !open.UpdatedAt.Equal(expected) {
+		t.Errorf("Expected updated %s, got %s", expected, open.UpdatedAt)
+	}
+
+	closed := tasks[1]
+	if closed.Status != models.StatusDone || closed.Milestone != "v1.0" || closed.Label != "" || closed.Field(LabelsField) != "" {
+		t.Errorf("Expected closed issue in milestone v1.0 without label, got %+v", closed)
+	}
+	completed, ok := closed.CompletedAt(workflow)
+	if expected := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC); !ok || !completed.Equal(expected) {
+		t.Errorf("Expected completion at %s, got %s", expected, completed)
+	}
+}
+
+func TestGitHubUnsupported(t *testing.T) {
+	format := NewGitHub(models.DefaultWorkflow())
+
+	if err := format.Encode(&bytes.Buffer{}, nil); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+
+	if _, err := format.Decode(strings.NewReader(`{"number": 1}`)); err == nil {
+		t.Error("Expected error for a JSON object, got nil")
+	}
+}
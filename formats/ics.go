@@ -0,0 +1,387 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// PriorityField is the custom field mapped onto priorities of external formats
+const PriorityField = "priority"
+
+// icsStatusProperty keeps the exact workflow status so that a round trip does
+// not collapse statuses sharing a category
+const icsStatusProperty = "X-CLI-TASK-MANAGER-STATUS"
+
+// icsLineLimit is the maximum length of a content line in octets (RFC 5545 3.1)
+const icsLineLimit = 75
+
+// ICS reads and writes tasks as iCalendar VTODO components (RFC 5545)
+type ICS struct {
+	workflow *models.Workflow
+}
+
+// NewICS creates an iCalendar format using the given workflow
+func NewICS(workflow *models.Workflow) *ICS {
+	return &ICS{workflow: workflow}
+}
+
+// Encode writes the tasks as a VCALENDAR holding one VTODO per task
+func (f *ICS) Encode(w io.Writer, tasks []models.Task) error {
+	bw := bufio.NewWriter(w)
+
+	writeICSLine(bw, "BEGIN", "VCALENDAR")
+	writeICSLine(bw, "VERSION", "2.0")
+	writeICSLine(bw, "PRODID", "-//mstgnz//cli-task-manager//EN")
+
+	for _, task := range tasks {
+		writeICSLine(bw, "BEGIN", "VTODO")
+		writeICSLine(bw, "UID", fmt.Sprintf("%d-%d@cli-task-manager", task.ID, task.CreatedAt.Unix()))
+		writeICSLine(bw, "DTSTAMP", formatICSTime(task.UpdatedAt))
+		writeICSLine(bw, "CREATED", formatICSTime(task.CreatedAt))
+		writeICSLine(bw, "LAST-MODIFIED", formatICSTime(task.UpdatedAt))
+		writeICSLine(bw, "SUMMARY", escapeICSText(task.Title))
+		if task.Description != "" {
+			writeICSLine(bw, "DESCRIPTION", escapeICSText(task.Description))
+		}
+		if task.Label != "" {
+			writeICSLine(bw, "CATEGORIES", escapeICSText(task.Label))
+		}
+		if task.Due != nil {
+			writeICSLine(bw, "DUE;VALUE=DATE", task.Due.Format("20060102"))
+		}
+		if priority := icsPriority(task.Field(PriorityField)); priority > 0 {
+			writeICSLine(bw, "PRIORITY", strconv.Itoa(priority))
+		}
+		writeICSLine(bw, "STATUS", f.icsStatus(task.Status))
+		if completed, ok := task.CompletedAt(f.workflow); ok {
+			writeICSLine(bw, "COMPLETED", formatICSTime(completed))
+		}
+		writeICSLine(bw, icsStatusProperty, escapeICSText(string(task.Status)))
+		writeICSLine(bw, "END", "VTODO")
+	}
+
+	writeICSLine(bw, "END", "VCALENDAR")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return nil
+}
+
+// Decode reads every VTODO component of a calendar as a task
+func (f *ICS) Decode(r io.Reader) ([]models.Task, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tasks      []models.Task
+		task       *models.Task
+		components []string
+		completed  time.Time
+		status     models.Status
+	)
+
+	for i, line := range lines {
+		name, params, value, err := parseICSLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch name {
+		case "BEGIN":
+			components = append(components, strings.ToUpper(value))
+			if strings.EqualFold(value, "VTODO") && len(components) == 2 {
+				now := time.Now()
+				task = &models.Task{Status: f.workflow.InitialStatus(), CreatedAt: now, UpdatedAt: now}
+				completed, status = time.Time{}, ""
+			}
+			continue
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, value)
+			}
+			components = components[:len(components)-1]
+			if task != nil && len(components) == 1 {
+				if task.Title == "" {
+					return nil, fmt.Errorf("line %d: VTODO without SUMMARY", i+1)
+				}
+				f.applyStatus(task, status, completed)
+				tasks = append(tasks, *task)
+				task = nil
+			}
+			continue
+		}
+
+		// Only read properties of the VTODO itself, not of nested alarms
+		if task == nil || len(components) != 2 {
+			continue
+		}
+
+		switch name {
+		case "SUMMARY":
+			task.Title = unescapeICSText(value)
+		case "DESCRIPTION":
+			task.Description = unescapeICSText(value)
+		case "CATEGORIES":
+			if categories := splitICSList(value); len(categories) > 0 && task.Label == "" {
+				task.Label = categories[0]
+			}
+		case "DUE":
+			due, err := parseICSTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid DUE: %w", i+1, err)
+			}
+			year, month, day := due.Local().Date()
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+			task.Due = &date
+		case "PRIORITY":
+			priority, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid PRIORITY %q", i+1, value)
+			}
+			task.SetField(PriorityField, priorityName(priority))
+		case "STATUS":
+			if status == "" {
+				status = f.taskStatus(strings.ToUpper(value))
+			}
+		case icsStatusProperty:
+			if candidate := models.Status(unescapeICSText(value)); f.workflow.IsValid(candidate) {
+				status = candidate
+			}
+		case "CREATED":
+			if created, err := parseICSTime(value, params); err == nil {
+				task.CreatedAt = created
+			}
+		case "LAST-MODIFIED":
+			if modified, err := parseICSTime(value, params); err == nil {
+				task.UpdatedAt = modified
+			}
+		case "COMPLETED":
+			if at, err := parseICSTime(value, params); err == nil {
+				completed = at
+			}
+		}
+	}
+
+	if len(components) != 0 {
+		return nil, fmt.Errorf("unterminated %s component", components[len(components)-1])
+	}
+
+	return tasks, nil
+}
+
+// applyStatus sets the decoded status, recording when a closed task was completed
+func (f *ICS) applyStatus(task *models.Task, status models.Status, completed time.Time) {
+	if status == "" {
+		return
+	}
+	if f.workflow.IsClosed(status) && !completed.IsZero() {
+		task.SetStatus(status, completed)
+		return
+	}
+	task.Status = status
+}
+
+// icsStatus maps a workflow status onto a VTODO status
+func (f *ICS) icsStatus(status models.Status) string {
+	switch f.workflow.Category(status) {
+	case models.CategoryActive:
+		return "IN-PROCESS"
+	case models.CategoryClosed:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// taskStatus maps a VTODO status onto a workflow status
+func (f *ICS) taskStatus(status string) models.Status {
+	switch status {
+	case "IN-PROCESS":
+		return statusFor(f.workflow, models.CategoryActive)
+	case "COMPLETED", "CANCELLED":
+		return statusFor(f.workflow, models.CategoryClosed)
+	default:
+		return statusFor(f.workflow, models.CategoryOpen)
+	}
+}
+
+// icsPriority maps a priority field value onto the 1 (highest) to 9 (lowest)
+// scale, returning 0 when the value is empty or unknown
+func icsPriority(value string) int {
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 9 {
+		return n
+	}
+
+	switch strings.ToLower(value) {
+	case "critical", "urgent", "high":
+		return 1
+	case "medium", "normal":
+		return 5
+	case "low":
+		return 9
+	default:
+		return 0
+	}
+}
+
+// priorityName maps a 1 to 9 priority onto high, medium or low as suggested
+// by RFC 5545, returning an empty string for an undefined priority
+func priorityName(priority int) string {
+	switch {
+	case priority >= 1 && priority <= 4:
+		return "high"
+	case priority == 5:
+		return "medium"
+	case priority >= 6 && priority <= 9:
+		return "low"
+	default:
+		return ""
+	}
+}
+
+// writeICSLine writes a content line, folding it at the line limit
+func writeICSLine(w *bufio.Writer, name, value string) {
+	line := name + ":" + value
+
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > icsLineLimit {
+			w.WriteString("\r\n ")
+			width = 1
+		}
+		w.WriteRune(r)
+		width += size
+	}
+	w.WriteString("\r\n")
+}
+
+// unfoldICSLines reads the content lines of a calendar, joining folded lines
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseICSLine splits a content line into its upper-cased name, its
+// parameters and its value
+func parseICSLine(line string) (string, map[string]string, string, error) {
+	// The value starts at the first colon outside a quoted parameter value
+	colon, quoted := -1, false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string)
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+// formatICSTime formats a time as a UTC date-time
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// parseICSTime parses a date or date-time value. Floating times and times
+// with an unknown TZID are read in the local time zone.
+func parseICSTime(value string, params map[string]string) (time.Time, error) {
+	loc := time.Local
+	if tzid, ok := params["TZID"]; ok {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+
+	switch {
+	case len(value) == 8:
+		return time.ParseInLocation("20060102", value, time.Local)
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+// escapeICSText escapes a TEXT value
+func escapeICSText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// unescapeICSText reverses escapeICSText
+func unescapeICSText(value string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+
+	return b.String()
+}
+
+// splitICSList splits a list value on unescaped commas and unescapes each item
+func splitICSList(value string) []string {
+	var items []string
+
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, unescapeICSText(value[start:i]))
+			start = i + 1
+		}
+	}
+	items = append(items, unescapeICSText(value[start:]))
+
+	return items
+}
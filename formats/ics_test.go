@@ -0,0 +1,166 @@
+package formats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestICSRoundTrip(t *testing.T) {
+	workflow := &models.Workflow{
+		Statuses: []models.StatusDefinition{
+			{Name: "backlog", Category: models.CategoryOpen},
+			{Name: "doing", Category: models.CategoryActive},
+			{Name: "shipped", Category: models.CategoryClosed},
+			{Name: "wontfix", Category: models.CategoryClosed},
+		},
+	}
+	format := NewICS(workflow)
+
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(48 * time.Hour)
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+
+	shipped := models.Task{
+		ID:          1,
+		Title:       "Fix login, again; really",
+		Description: "Line one\nLine two with a backslash \\",
+		Label:       "bug",
+		Status:      "backlog",
+		CreatedAt:   created,
+		UpdatedAt:   closed,
+		Due:         &due,
+	}
+	shipped.SetField(PriorityField, "high")
+	shipped.SetStatus("shipped", closed)
+
+	rejected := models.Task{ID: 2, Title: strings.Repeat("long title ", 12), Label: "feature", Status: "wontfix", CreatedAt: created, UpdatedAt: created}
+
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, []models.Task{shipped, rejected}); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Errorf("Line longer than %d octets: %q", icsLineLimit, line)
+		}
+	}
+
+	tasks, err := format.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode: unexpected error %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	got := tasks[0]
+	if got.Title != shipped.Title || got.Description != shipped.Description || got.Label != "bug" {
+		t.Errorf("Expected text fields to round trip, got %+v", got)
+	}
+	if got.Status != "shipped" {
+		t.Errorf("Expected status shipped, got %s", got.Status)
+	}
+	if completed, ok := got.CompletedAt(workflow); !ok || !completed.Equal(closed) {
+		t.Errorf("Expected completion at %s, got %s", closed, completed)
+	}
+	if got.Due == nil || !got.Due.Equal(due) {
+		t.Errorf("Expected due %s, got %v", due, got.Due)
+	}
+	if got.Field(PriorityField) != "high" {
+		t.Errorf("Expected priority high, got %q", got.Field(PriorityField))
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("Expected created %s, got %s", created, got.CreatedAt)
+	}
+
+	if tasks[1].Title != rejected.Title || tasks[1].Status != "wontfix" {
+		t.Errorf("Expected folded title and wontfix status, got %+v", tasks[1])
+	}
+}
+
+func TestICSDecodeForeignCalendar(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Meeting",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"SUMMARY:Write the",
+		"  report",
+		"STATUS:IN-PROCESS",
+		"CATEGORIES:docs,writing",
+		"PRIORITY:7",
+		"DUE;TZID=\"Europe/Istanbul\":20240320T230000",
+		"BEGIN:VALARM",
+		"SUMMARY:Alarm",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"SUMMARY:Cancelled",
+		"STATUS:CANCELLED",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\n")
+
+	tasks, err := NewICS(models.DefaultWorkflow()).Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode: unexpected error %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.Title != "Write the report" {
+		t.Errorf("Expected unfolded title, got %q", task.Title)
+	}
+	if task.Status != models.StatusInProgress {
+		t.Errorf("Expected status in-progress, got %s", task.Status)
+	}
+	if task.Label != "docs" {
+		t.Errorf("Expected first category as label, got %q", task.Label)
+	}
+	if task.Field(PriorityField) != "low" {
+		t.Errorf("Expected priority low, got %q", task.Field(PriorityField))
+	}
+	if task.Due == nil {
+		t.Error("Expected a due date, got nil")
+	}
+
+	if tasks[1].Status != models.StatusDone {
+		t.Errorf("Expected cancelled task to be done, got %s", tasks[1].Status)
+	}
+}
+
+func TestICSDecodeErrors(t *testing.T) {
+	inputs := []string{
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY:Open\n",
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VTODO\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY:Bad\nDUE:tomorrow\nEND:VTODO\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nnot a content line\nEND:VCALENDAR\n",
+	}
+
+	for _, input := range inputs {
+		if _, err := NewICS(models.DefaultWorkflow()).Decode(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %q, got nil", input)
+		}
+	}
+}
+
+func TestICSPriority(t *testing.T) {
+	tests := map[string]int{"": 0, "high": 1, "Medium": 5, "low": 9, "3": 3, "someday": 0, "12": 0}
+
+	for value, expected := range tests {
+		if got := icsPriority(value); got != expected {
+			t.Errorf("icsPriority(%q): expected %d, got %d", value, expected, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package formats
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Groupings supported by the Markdown format
+const (
+	GroupByStatus = "status"
+	GroupByLabel  = "label"
+)
+
+// Markdown writes tasks as checklists with one section per status or label
+type Markdown struct {
+	workflow *models.Workflow
+	groupBy  string
+}
+
+// NewMarkdown creates a Markdown format grouping tasks by status (the
+// default) or by label
+func NewMarkdown(workflow *models.Workflow, groupBy string) (*Markdown, error) {
+	switch groupBy {
+	case "":
+		groupBy = GroupByStatus
+	case GroupByStatus, GroupByLabel:
+	default:
+		return nil, fmt.Errorf("invalid grouping %q, expected %s or %s", groupBy, GroupByStatus, GroupByLabel)
+	}
+	return &Markdown{workflow: workflow, groupBy: groupBy}, nil
+}
+
+// Encode writes one checklist section per group, ticking closed tasks
+func (f *Markdown) Encode(w io.Writer, tasks []models.Task) error {
+	groups := make(map[string][]models.Task)
+	for _, task := range tasks {
+		key := string(task.Status)
+		if f.groupBy == GroupByLabel {
+			key = task.Label
+		}
+		groups[key] = append(groups[key], task)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("# Tasks\n")
+
+	for _, key := range f.groupOrder(groups) {
+		title := key
+		if title == "" {
+			title = "(none)"
+		}
+		fmt.Fprintf(bw, "\n## %s\n\n", title)
+
+		entries := groups[key]
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+		for _, task := range entries {
+			bw.WriteString(f.checklistItem(task))
+			bw.WriteString("\n")
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
+
+// Decode is not supported, Markdown checklists can only be exported
+func (f *Markdown) Decode(r io.Reader) ([]models.Task, error) {
+	return nil, fmt.Errorf("the markdown format can only be exported: %w", errors.ErrUnsupported)
+}
+
+// groupOrder returns the group keys: statuses in workflow order, labels sorted
+func (f *Markdown) groupOrder(groups map[string][]models.Task) []string {
+	var keys []string
+
+	if f.groupBy == GroupByStatus {
+		for _, status := range f.workflow.Names() {
+			if _, ok := groups[string(status)]; ok {
+				keys = append(keys, string(status))
+			}
+		}
+	}
+
+	// Labels, and statuses no longer declared by the workflow, are sorted
+	var rest []string
+	for key := range groups {
+		if f.groupBy != GroupByStatus || !f.workflow.IsValid(models.Status(key)) {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// checklistItem formats a task as a checklist item
+func (f *Markdown) checklistItem(task models.Task) string {
+	box := "[ ]"
+	if f.workflow.IsClosed(task.Status) {
+		box = "[x]"
+	}
+
+	var details []string
+	if f.groupBy == GroupByStatus && task.Label != "" {
+		details = append(details, "`"+task.Label+"`")
+	}
+	if f.groupBy == GroupByLabel {
+		details = append(details, string(task.Status))
+	}
+	if task.Due != nil {
+		details = append(details, "due "+task.Due.Format(models.DateLayout))
+	}
+	if task.Milestone != "" {
+		details = append(details, "milestone "+task.Milestone)
+	}
+
+	item := fmt.Sprintf("- %s #%d %s", box, task.ID, task.Title)
+	if len(details) > 0 {
+		item += " (" + strings.Join(details, ", ") + ")"
+	}
+	return item
+}
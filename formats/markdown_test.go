@@ -0,0 +1,71 @@
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestMarkdownEncode(t *testing.T) {
+	due := time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)
+	tasks := []models.Task{
+		{ID: 2, Title: "Ship it", Label: "feature", Status: models.StatusDone},
+		{ID: 1, Title: "Fix login", Label: "bug", Status: models.StatusTodo, Due: &due, Milestone: "v1.0"},
+		{ID: 3, Title: "Legacy", Label: "bug", Status: "archived"},
+	}
+
+	byStatus, err := NewMarkdown(models.DefaultWorkflow(), "")
+	if err != nil {
+		t.Fatalf("NewMarkdown: unexpected error %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := byStatus.Encode(&buf, tasks); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"# Tasks",
+		"",
+		"## to-do",
+		"",
+		"- [ ] #1 Fix login (`bug`, due 2024-03-10, milestone v1.0)",
+		"",
+		"## done",
+		"",
+		"- [x] #2 Ship it (`feature`)",
+		"",
+		"## archived",
+		"",
+		"- [ ] #3 Legacy (`bug`)",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	byLabel, err := NewMarkdown(models.DefaultWorkflow(), GroupByLabel)
+	if err != nil {
+		t.Fatalf("NewMarkdown: unexpected error %v", err)
+	}
+
+	buf.Reset()
+	if err := byLabel.Encode(&buf, tasks); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+	if !strings.Contains(buf.String(), "## bug\n\n- [ ] #1 Fix login (to-do, due 2024-03-10, milestone v1.0)\n- [ ] #3 Legacy (archived)\n\n## feature") {
+		t.Errorf("Expected sections per label, got:\n%s", buf.String())
+	}
+
+	if _, err := byLabel.Decode(strings.NewReader("")); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+
+	if _, err := NewMarkdown(models.DefaultWorkflow(), "milestone"); err == nil {
+		t.Error("Expected error for unknown grouping, got nil")
+	}
+}
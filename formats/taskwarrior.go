@@ -0,0 +1,367 @@
+package formats
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Custom fields used by the Taskwarrior format
+const (
+	// TaskwarriorUUIDField records the uuid of imported tasks
+	TaskwarriorUUIDField = "taskwarrior_uuid"
+
+	// ProjectField holds the Taskwarrior project of a task
+	ProjectField = "project"
+
+	// taskwarriorFieldPrefix prefixes the fields preserving attributes that
+	// have no task equivalent, such as wait, recur or user defined attributes
+	taskwarriorFieldPrefix = "taskwarrior_"
+
+	// taskwarriorAnnotationEntries names the preserved attribute holding the
+	// entry timestamps of the annotations, in the order of their lines in
+	// the description
+	taskwarriorAnnotationEntries = "annotation_entries"
+)
+
+// taskwarriorTimeLayout is the layout of Taskwarrior timestamps
+const taskwarriorTimeLayout = "20060102T150405Z"
+
+// taskwarriorComputed lists attributes computed by Taskwarrior that are not imported
+var taskwarriorComputed = map[string]bool{"id": true, "urgency": true}
+
+// taskwarriorPriorities maps Taskwarrior priorities onto priority field values
+var taskwarriorPriorities = map[string]string{"H": "high", "M": "medium", "L": "low"}
+
+// Taskwarrior reads and writes tasks in the JSON format of `task export` and `task import`
+type Taskwarrior struct {
+	workflow *models.Workflow
+}
+
+// taskwarriorAnnotation is a note attached to a Taskwarrior task
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// NewTaskwarrior creates a Taskwarrior format using the given workflow
+func NewTaskwarrior(workflow *models.Workflow) *Taskwarrior {
+	return &Taskwarrior{workflow: workflow}
+}
+
+// KeyField returns the field identifying imported tasks
+func (f *Taskwarrior) KeyField() string {
+	return TaskwarriorUUIDField
+}
+
+// Encode writes the tasks as a JSON array of Taskwarrior tasks
+func (f *Taskwarrior) Encode(w io.Writer, tasks []models.Task) error {
+	records := make([]map[string]any, 0, len(tasks))
+	for _, task := range tasks {
+		records = append(records, f.encodeTask(task))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to write Taskwarrior tasks: %w", err)
+	}
+	return nil
+}
+
+// encodeTask converts a task into a Taskwarrior task
+func (f *Taskwarrior) encodeTask(task models.Task) map[string]any {
+	record := make(map[string]any)
+
+	// Preserved attributes are written first so that mapped ones take precedence
+	for name, value := range task.Fields {
+		attribute, ok := strings.CutPrefix(name, taskwarriorFieldPrefix)
+		if !ok || name == TaskwarriorUUIDField {
+			continue
+		}
+		if raw := json.RawMessage(value); json.Valid(raw) {
+			record[attribute] = raw
+		} else {
+			record[attribute] = value
+		}
+	}
+
+	uuid := task.Field(TaskwarriorUUIDField)
+	if uuid == "" {
+		uuid = taskUUID(task)
+	}
+
+	record["uuid"] = uuid
+	record["description"] = task.Title
+	record["entry"] = task.CreatedAt.UTC().Format(taskwarriorTimeLayout)
+	record["modified"] = task.UpdatedAt.UTC().Format(taskwarriorTimeLayout)
+
+	switch f.workflow.Category(task.Status) {
+	case models.CategoryClosed:
+		// Deleted tasks stay deleted unless they were reopened in between
+		if record["status"] != "deleted" {
+			record["status"] = "completed"
+		}
+		if completed, ok := task.CompletedAt(f.workflow); ok {
+			record["end"] = completed.UTC().Format(taskwarriorTimeLayout)
+		}
+	case models.CategoryActive:
+		record["status"] = "pending"
+		record["start"] = f.startedAt(task).UTC().Format(taskwarriorTimeLayout)
+	default:
+		if record["status"] != "waiting" && record["status"] != "recurring" {
+			record["status"] = "pending"
+		}
+	}
+
+	var tags []string
+	if task.Label != "" {
+		tags = append(tags, singleWord(task.Label))
+	}
+	if extra, ok := record["tags"].(json.RawMessage); ok {
+		var more []string
+		if json.Unmarshal(extra, &more) == nil {
+			tags = append(tags, more...)
+		}
+	}
+	delete(record, "tags")
+	if len(tags) > 0 {
+		record["tags"] = tags
+	}
+
+	if project := task.Field(ProjectField); project != "" {
+		record["project"] = project
+	}
+	if task.Due != nil {
+		record["due"] = task.Due.UTC().Format(taskwarriorTimeLayout)
+	}
+	if priority := taskwarriorPriority(task.Field(PriorityField)); priority != "" {
+		record["priority"] = priority
+	}
+
+	var entries []string
+	if raw, ok := record[taskwarriorAnnotationEntries].(json.RawMessage); ok {
+		json.Unmarshal(raw, &entries)
+	}
+	delete(record, taskwarriorAnnotationEntries)
+
+	if task.Description != "" {
+		var annotations []taskwarriorAnnotation
+		for i, line := range strings.Split(task.Description, "\n") {
+			// Lines added since the import are dated with the task
+			entry := task.CreatedAt.UTC().Format(taskwarriorTimeLayout)
+			if i < len(entries) {
+				entry = entries[i]
+			}
+			annotations = append(annotations, taskwarriorAnnotation{Entry: entry, Description: line})
+		}
+		record["annotations"] = annotations
+	}
+
+	return record
+}
+
+// startedAt returns when an active task last became active
+func (f *Taskwarrior) startedAt(task models.Task) time.Time {
+	for i := len(task.History) - 1; i >= 0; i-- {
+		if f.workflow.Category(task.History[i].To) == models.CategoryActive {
+			return task.History[i].At
+		}
+	}
+	return task.UpdatedAt
+}
+
+// Decode reads a JSON array of Taskwarrior tasks
+func (f *Taskwarrior) Decode(r io.Reader) ([]models.Task, error) {
+	var records []map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to parse Taskwarrior tasks: %w", err)
+	}
+
+	var (
+		tasks     []models.Task
+		rowErrors RowErrors
+	)
+
+	for i, record := range records {
+		task, err := f.decodeTask(record)
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: i + 1, Err: err})
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(rowErrors) > 0 {
+		return tasks, rowErrors
+	}
+	return tasks, nil
+}
+
+// decodeTask converts a Taskwarrior task into a task
+func (f *Taskwarrior) decodeTask(record map[string]json.RawMessage) (models.Task, error) {
+	var (
+		task        models.Task
+		status      string
+		uuid        string
+		tags        []string
+		priority    string
+		annotations []taskwarriorAnnotation
+		start, end  time.Time
+	)
+
+	now := time.Now()
+	task.CreatedAt, task.UpdatedAt = now, now
+
+	for name, raw := range record {
+		var err error
+		switch name {
+		case "description":
+			err = json.Unmarshal(raw, &task.Title)
+		case "uuid":
+			err = json.Unmarshal(raw, &uuid)
+		case "status":
+			err = json.Unmarshal(raw, &status)
+		case "tags":
+			err = json.Unmarshal(raw, &tags)
+		case "project":
+			var project string
+			err = json.Unmarshal(raw, &project)
+			task.SetField(ProjectField, project)
+		case "priority":
+			err = json.Unmarshal(raw, &priority)
+		case "annotations":
+			err = json.Unmarshal(raw, &annotations)
+		case "due":
+			var due time.Time
+			due, err = parseTaskwarriorTime(raw)
+			year, month, day := due.Local().Date()
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+			task.Due = &date
+		case "entry":
+			task.CreatedAt, err = parseTaskwarriorTime(raw)
+		case "modified":
+			task.UpdatedAt, err = parseTaskwarriorTime(raw)
+		case "start":
+			start, err = parseTaskwarriorTime(raw)
+		case "end":
+			end, err = parseTaskwarriorTime(raw)
+		default:
+			if !taskwarriorComputed[name] {
+				task.SetField(taskwarriorFieldPrefix+name, preservedValue(raw))
+			}
+		}
+		if err != nil {
+			return models.Task{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if strings.TrimSpace(task.Title) == "" {
+		return models.Task{}, errors.New("task has no description")
+	}
+	if uuid == "" {
+		return models.Task{}, fmt.Errorf("task %q has no uuid", task.Title)
+	}
+	task.SetField(TaskwarriorUUIDField, uuid)
+
+	if len(tags) > 0 {
+		task.Label = tags[0]
+	}
+	if len(tags) > 1 {
+		extra, _ := json.Marshal(tags[1:])
+		task.SetField(taskwarriorFieldPrefix+"tags", string(extra))
+	}
+
+	if name, ok := taskwarriorPriorities[priority]; ok {
+		task.SetField(PriorityField, name)
+	}
+
+	var notes, entries []string
+	for _, annotation := range annotations {
+		notes = append(notes, annotation.Description)
+		entries = append(entries, annotation.Entry)
+	}
+	task.Description = strings.Join(notes, "\n")
+	if len(entries) > 0 {
+		raw, _ := json.Marshal(entries)
+		task.SetField(taskwarriorFieldPrefix+taskwarriorAnnotationEntries, string(raw))
+	}
+
+	task.Status = statusFor(f.workflow, models.CategoryOpen)
+	switch status {
+	case "pending", "waiting", "recurring", "":
+		if !start.IsZero() {
+			task.SetStatus(statusFor(f.workflow, models.CategoryActive), start)
+		}
+		if status == "waiting" || status == "recurring" {
+			task.SetField(taskwarriorFieldPrefix+"status", status)
+		}
+	case "completed", "deleted":
+		if end.IsZero() {
+			end = task.UpdatedAt
+		}
+		task.SetStatus(statusFor(f.workflow, models.CategoryClosed), end)
+		if status == "deleted" {
+			task.SetField(taskwarriorFieldPrefix+"status", status)
+		}
+	default:
+		return models.Task{}, fmt.Errorf("task %q has unknown status %q", task.Title, status)
+	}
+
+	return task, nil
+}
+
+// parseTaskwarriorTime parses a JSON encoded Taskwarrior timestamp
+func parseTaskwarriorTime(raw json.RawMessage) (time.Time, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(taskwarriorTimeLayout, value)
+}
+
+// preservedValue returns a string attribute verbatim and any other value as
+// JSON. Strings that are valid JSON themselves, such as "42" or "true", are
+// kept as JSON strings so that they are exported as strings again.
+func preservedValue(raw json.RawMessage) string {
+	var value string
+	if json.Unmarshal(raw, &value) == nil && !json.Valid([]byte(value)) {
+		return value
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, raw) != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
+
+// taskwarriorPriority maps a priority field value onto H, M or L
+func taskwarriorPriority(value string) string {
+	switch priority := icsPriority(value); {
+	case priority >= 1 && priority <= 4:
+		return "H"
+	case priority == 5:
+		return "M"
+	case priority >= 6:
+		return "L"
+	default:
+		return ""
+	}
+}
+
+// taskUUID derives a stable name-based (version 5 style) UUID for a task
+// that was not imported from Taskwarrior
+func taskUUID(task models.Task) string {
+	sum := sha1.Sum([]byte("cli-task-manager:" + strconv.Itoa(task.ID) + ":" + strconv.FormatInt(task.CreatedAt.UnixNano(), 10)))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,144 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+const taskwarriorExport = `[
+	{"id": 1, "uuid": "6fd0ba4a-0000-4000-8000-000000000001", "description": "Fix the fence",
+	 "status": "pending", "entry": "20240301T100000Z", "modified": "20240302T100000Z",
+	 "start": "20240302T090000Z", "due": "20240310T120000Z", "project": "house", "priority": "H",
+	 "tags": ["home", "garden"], "annotations": [{"entry": "20240301T100000Z", "description": "Buy wood"},
+	 {"entry": "20240303T080000Z", "description": "Call Bob"}],
+	 "urgency": 8.2, "wait": "20240305T000000Z", "estimate_points": 3, "ticket": "42", "reviewed": "true"},
+	{"id": 0, "uuid": "6fd0ba4a-0000-4000-8000-000000000002", "description": "Old idea",
+	 "status": "deleted", "entry": "20240301T100000Z", "end": "20240304T100000Z"},
+	{"id": 2, "description": "No uuid", "status": "pending"},
+	{"id": 3, "uuid": "6fd0ba4a-0000-4000-8000-000000000003", "description": "Odd", "status": "blocked"}
+]`
+
+func TestTaskwarriorDecode(t *testing.T) {
+	workflow := models.DefaultWorkflow()
+	tasks, err := NewTaskwarrior(workflow).Decode(strings.NewReader(taskwarriorExport))
+
+	var rowErrors RowErrors
+	if !errors.As(err, &rowErrors) || len(rowErrors) != 2 || rowErrors[0].Row != 3 || rowErrors[1].Row != 4 {
+		t.Fatalf("Expected tasks 3 and 4 to fail, got %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.Title != "Fix the fence" || task.Description != "Buy wood\nCall Bob" || task.Label != "home" {
+		t.Errorf("Expected description, annotations and first tag to be mapped, got %+v", task)
+	}
+	if task.Status != models.StatusInProgress {
+		t.Errorf("Expected a started task to be in progress, got %s", task.Status)
+	}
+	if task.Field(ProjectField) != "house" || task.Field(PriorityField) != "high" {
+		t.Errorf("Expected project and priority fields, got %v", task.Fields)
+	}
+	if task.Field(TaskwarriorUUIDField) != "6fd0ba4a-0000-4000-8000-000000000001" {
+		t.Errorf("Expected uuid to be recorded, got %q", task.Field(TaskwarriorUUIDField))
+	}
+	if task.Field("taskwarrior_wait") != "20240305T000000Z" || task.Field("taskwarrior_estimate_points") != "3" || task.Field("taskwarrior_tags") != `["garden"]` {
+		t.Errorf("Expected unmapped attributes to be preserved, got %v", task.Fields)
+	}
+	if task.Field("taskwarrior_urgency") != "" || task.Field("taskwarrior_id") != "" {
+		t.Errorf("Expected computed attributes to be dropped, got %v", task.Fields)
+	}
+	if expected := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC); !task.CreatedAt.Equal(expected) {
+		t.Errorf("Expected created %s, got %s", expected, task.CreatedAt)
+	}
+
+	deleted := tasks[1]
+	completed, ok := deleted.CompletedAt(workflow)
+	if deleted.Status != models.StatusDone || !ok || !completed.Equal(time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a deleted task to be done at its end time, got %+v", deleted)
+	}
+}
+
+func TestTaskwarriorRoundTrip(t *testing.T) {
+	format := NewTaskwarrior(models.DefaultWorkflow())
+
+	tasks, _ := format.Decode(strings.NewReader(taskwarriorExport))
+
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, tasks); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+
+	var records []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("Expected a JSON array, got %v", err)
+	}
+
+	record := records[0]
+	if record["uuid"] != "6fd0ba4a-0000-4000-8000-000000000001" || record["status"] != "pending" || record["start"] != "20240302T090000Z" {
+		t.Errorf("Expected uuid, status and start to round trip, got %v", record)
+	}
+	if record["priority"] != "H" || record["project"] != "house" || record["wait"] != "20240305T000000Z" || record["estimate_points"] != float64(3) {
+		t.Errorf("Expected mapped and preserved attributes, got %v", record)
+	}
+	if tags, ok := record["tags"].([]any); !ok || len(tags) != 2 || tags[0] != "home" || tags[1] != "garden" {
+		t.Errorf("Expected tags home and garden, got %v", record["tags"])
+	}
+	if record["ticket"] != "42" || record["reviewed"] != "true" {
+		t.Errorf("Expected string attributes that look like JSON to stay strings, got %v and %v", record["ticket"], record["reviewed"])
+	}
+	if _, ok := record[taskwarriorAnnotationEntries]; ok {
+		t.Errorf("Expected the annotation entries not to be exported as an attribute, got %v", record)
+	}
+
+	annotations, _ := record["annotations"].([]any)
+	var entries []any
+	for _, annotation := range annotations {
+		entries = append(entries, annotation.(map[string]any)["entry"])
+	}
+	if len(entries) != 2 || entries[0] != "20240301T100000Z" || entries[1] != "20240303T080000Z" {
+		t.Errorf("Expected the annotation entries to round trip, got %v", entries)
+	}
+
+	if records[1]["status"] != "deleted" || records[1]["end"] != "20240304T100000Z" {
+		t.Errorf("Expected the deleted task to stay deleted, got %v", records[1])
+	}
+
+	again, err := format.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode: unexpected error %v", err)
+	}
+	if len(again) != 2 || again[0].Field(TaskwarriorUUIDField) != tasks[0].Field(TaskwarriorUUIDField) {
+		t.Errorf("Expected the export to decode again, got %v", again)
+	}
+}
+
+func TestTaskwarriorEncodeNewTask(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	task := models.Task{ID: 4, Title: "Local task", Label: "bug fix", Status: models.StatusDone, CreatedAt: created, UpdatedAt: created}
+
+	format := NewTaskwarrior(models.DefaultWorkflow())
+	record := format.encodeTask(task)
+
+	uuid, _ := record["uuid"].(string)
+	if len(uuid) != 36 || uuid[14] != '5' {
+		t.Errorf("Expected a version 5 UUID, got %q", uuid)
+	}
+	if again := format.encodeTask(task); again["uuid"] != uuid {
+		t.Errorf("Expected a stable UUID, got %v and %v", uuid, again["uuid"])
+	}
+	if record["status"] != "completed" || record["end"] != "20240301T100000Z" {
+		t.Errorf("Expected a completed task, got %v", record)
+	}
+	if tags, ok := record["tags"].([]string); !ok || len(tags) != 1 || tags[0] != "bug_fix" {
+		t.Errorf("Expected the label as single word tag, got %v", record["tags"])
+	}
+}
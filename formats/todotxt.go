@@ -0,0 +1,440 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// ContextField is the custom field holding the @context of todo.txt tasks
+const ContextField = "context"
+
+// todoTxtTag matches key:value tags, leaving times such as 10:30 and URLs
+// such as https://host alone
+var todoTxtTag = regexp.MustCompile(`^[A-Za-z][^\s:]*:[^\s/]\S*$`)
+
+// todoTxtPriorities maps todo.txt priorities onto priority field values
+var todoTxtPriorities = map[string]string{"A": "high", "B": "medium", "C": "low"}
+
+// TodoTxt reads and writes tasks in the todo.txt format, one task per line:
+//
+//	x (A) 2024-03-05 2024-03-01 Title +label @context due:2024-03-10 key:value
+//
+// The +project is mapped onto the label, the priority onto the priority field,
+// the due, milestone and estimate tags onto the matching task attributes and
+// the @context and other key:value tags onto custom fields.
+type TodoTxt struct {
+	workflow *models.Workflow
+	// extended keeps the attributes todo.txt has no syntax for in tags
+	extended bool
+}
+
+// Tags holding the attributes of extended todo.txt lines
+const (
+	todoTxtDescriptionTag = "desc"
+	todoTxtCreatedTag     = "created"
+	todoTxtUpdatedTag     = "updated"
+	todoTxtRecurTag       = "recur"
+	todoTxtTimeTag        = "time"
+	todoTxtChangeTag      = "changed"
+)
+
+// NewTodoTxt creates a todo.txt format using the given workflow
+func NewTodoTxt(workflow *models.Workflow) *TodoTxt {
+	return &TodoTxt{workflow: workflow}
+}
+
+// NewExtendedTodoTxt creates a todo.txt format that also keeps the
+// description, exact creation and update times, recurrence, time entries and
+// status history of tasks in desc, created, updated, recur, time and changed
+// tags, and escapes title words that would read as todo.txt syntax with a
+// backslash, so that a todo.txt file can store tasks without losing them
+func NewExtendedTodoTxt(workflow *models.Workflow) *TodoTxt {
+	return &TodoTxt{workflow: workflow, extended: true}
+}
+
+// Encode writes one line per task
+func (f *TodoTxt) Encode(w io.Writer, tasks []models.Task) error {
+	bw := bufio.NewWriter(w)
+	for _, task := range tasks {
+		bw.WriteString(f.FormatLine(task))
+		bw.WriteString("\n")
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write todo.txt: %w", err)
+	}
+	return nil
+}
+
+// Decode reads one task per non-empty line. Tasks without a creation date are
+// considered created now.
+func (f *TodoTxt) Decode(r io.Reader) ([]models.Task, error) {
+	var (
+		tasks     []models.Task
+		rowErrors RowErrors
+	)
+
+	scanner := bufio.NewScanner(r)
+	for row := 1; scanner.Scan(); row++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		task, err := f.ParseLine(scanner.Text())
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = time.Now()
+			task.UpdatedAt = task.CreatedAt
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt: %w", err)
+	}
+
+	if len(rowErrors) > 0 {
+		return tasks, rowErrors
+	}
+	return tasks, nil
+}
+
+// FormatLine formats a task as a todo.txt line. Statuses other than the first
+// open and closed ones are kept in a status tag.
+func (f *TodoTxt) FormatLine(task models.Task) string {
+	var parts []string
+
+	closed := f.workflow.IsClosed(task.Status)
+	priority := todoTxtPriority(task.Field(PriorityField))
+
+	if closed {
+		parts = append(parts, "x")
+		if completed, ok := task.CompletedAt(f.workflow); ok && !completed.IsZero() {
+			parts = append(parts, completed.Format(models.DateLayout))
+		}
+	} else if priority != "" {
+		parts = append(parts, "("+priority+")")
+	}
+
+	if !task.CreatedAt.IsZero() {
+		parts = append(parts, task.CreatedAt.Format(models.DateLayout))
+	}
+
+	if f.extended {
+		parts = append(parts, escapeTodoTxtTitle(task.Title)...)
+	} else {
+		parts = append(parts, task.Title)
+	}
+
+	if task.Label != "" {
+		parts = append(parts, "+"+singleWord(task.Label))
+	}
+	if context := task.Field(ContextField); context != "" {
+		parts = append(parts, "@"+singleWord(context))
+	}
+	if task.Due != nil {
+		parts = append(parts, "due:"+task.Due.Format(models.DateLayout))
+	}
+	if task.Milestone != "" {
+		parts = append(parts, "milestone:"+singleWord(task.Milestone))
+	}
+	if task.Estimate != nil {
+		parts = append(parts, "estimate:"+task.Estimate.String())
+	}
+	if closed && priority != "" {
+		// Completed tasks keep their priority in a tag by convention
+		parts = append(parts, "pri:"+priority)
+	}
+	if task.Status != f.defaultStatus(closed) {
+		parts = append(parts, "status:"+singleWord(string(task.Status)))
+	}
+
+	names := make([]string, 0, len(task.Fields))
+	for name := range task.Fields {
+		if name != PriorityField && name != ContextField {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, singleWord(name)+":"+singleWord(task.Field(name)))
+	}
+
+	if f.extended {
+		parts = append(parts, extendedTags(task)...)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// escapeTodoTxtTitle splits a title into words, prefixing the words that
+// would be read as a completion mark, priority, date, +project, @context or
+// key:value tag, as well as words already starting with a backslash, with a
+// backslash
+func escapeTodoTxtTitle(title string) []string {
+	words := strings.Fields(title)
+	for i, word := range words {
+		_, isDate := todoTxtDate(words[i : i+1])
+		if word == "x" || isDate || isTodoTxtPriority(word) || todoTxtTag.MatchString(word) ||
+			(len(word) > 1 && strings.ContainsRune("+@\\", rune(word[0]))) {
+			words[i] = "\\" + word
+		}
+	}
+	return words
+}
+
+// extendedTags returns the tags of the attributes kept by extended lines.
+// Descriptions are query escaped, times use RFC 3339, the creation time is
+// left out while the creation date holds it and the update time while it
+// equals the creation time.
+func extendedTags(task models.Task) []string {
+	var tags []string
+
+	if task.Description != "" {
+		tags = append(tags, todoTxtDescriptionTag+":"+url.QueryEscape(task.Description))
+	}
+	if !task.CreatedAt.IsZero() {
+		date, _ := time.ParseInLocation(models.DateLayout, task.CreatedAt.Format(models.DateLayout), time.Local)
+		if !date.Equal(task.CreatedAt) {
+			tags = append(tags, todoTxtCreatedTag+":"+task.CreatedAt.Format(time.RFC3339Nano))
+		}
+	}
+	if !task.UpdatedAt.IsZero() && !task.UpdatedAt.Equal(task.CreatedAt) {
+		tags = append(tags, todoTxtUpdatedTag+":"+task.UpdatedAt.Format(time.RFC3339Nano))
+	}
+	if task.Recurrence != "" {
+		tags = append(tags, todoTxtRecurTag+":"+singleWord(task.Recurrence))
+	}
+	for _, entry := range task.TimeEntries {
+		tag := todoTxtTimeTag + ":" + entry.Start.Format(time.RFC3339Nano)
+		if entry.End != nil {
+			tag += "/" + entry.End.Format(time.RFC3339Nano)
+		}
+		tags = append(tags, tag)
+	}
+	for _, change := range task.History {
+		tags = append(tags, fmt.Sprintf("%s:%s,%s,%s", todoTxtChangeTag, singleWord(string(change.From)), singleWord(string(change.To)), change.At.Format(time.RFC3339Nano)))
+	}
+
+	return tags
+}
+
+// parseExtendedTag stores the value of an extended tag on the task. It
+// reports false for other tags.
+func parseExtendedTag(task *models.Task, key, value string) (bool, error) {
+	switch key {
+	case todoTxtDescriptionTag:
+		description, err := url.QueryUnescape(value)
+		if err != nil {
+			return true, fmt.Errorf("invalid description %q: %w", value, err)
+		}
+		task.Description = description
+	case todoTxtCreatedTag:
+		created, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return true, fmt.Errorf("invalid creation time %q", value)
+		}
+		task.CreatedAt = created
+	case todoTxtUpdatedTag:
+		updated, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return true, fmt.Errorf("invalid update time %q", value)
+		}
+		task.UpdatedAt = updated
+	case todoTxtRecurTag:
+		task.Recurrence = value
+	case todoTxtTimeTag:
+		startValue, endValue, hasEnd := strings.Cut(value, "/")
+		start, err := time.Parse(time.RFC3339Nano, startValue)
+		if err != nil {
+			return true, fmt.Errorf("invalid time entry %q", value)
+		}
+		entry := models.TimeEntry{Start: start}
+		if hasEnd {
+			end, err := time.Parse(time.RFC3339Nano, endValue)
+			if err != nil {
+				return true, fmt.Errorf("invalid time entry %q", value)
+			}
+			entry.End = &end
+		}
+		task.TimeEntries = append(task.TimeEntries, entry)
+	case todoTxtChangeTag:
+		parts := strings.SplitN(value, ",", 3)
+		if len(parts) != 3 {
+			return true, fmt.Errorf("invalid status change %q", value)
+		}
+		at, err := time.Parse(time.RFC3339Nano, parts[2])
+		if err != nil {
+			return true, fmt.Errorf("invalid status change %q", value)
+		}
+		task.History = append(task.History, models.StatusChange{From: models.Status(parts[0]), To: models.Status(parts[1]), At: at})
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// ParseLine parses a todo.txt line into a task without an ID. The creation
+// time is zero when the line has no creation date.
+func (f *TodoTxt) ParseLine(line string) (models.Task, error) {
+	words := strings.Fields(line)
+	task := models.Task{Status: f.defaultStatus(false)}
+
+	var completed time.Time
+	closed := len(words) > 0 && words[0] == "x"
+	if closed {
+		words = words[1:]
+		if date, ok := todoTxtDate(words); ok {
+			completed = date
+			words = words[1:]
+		}
+	} else if len(words) > 0 && isTodoTxtPriority(words[0]) {
+		task.SetField(PriorityField, todoTxtPriorityName(words[0][1:2]))
+		words = words[1:]
+	}
+
+	if date, ok := todoTxtDate(words); ok {
+		task.CreatedAt = date
+		task.UpdatedAt = date
+		words = words[1:]
+	}
+
+	status := f.defaultStatus(closed)
+	var extended models.Task
+	var title []string
+	for _, word := range words {
+		switch {
+		case f.extended && strings.HasPrefix(word, "\\") && len(word) > 1:
+			title = append(title, word[1:])
+		case strings.HasPrefix(word, "+") && len(word) > 1 && task.Label == "":
+			task.Label = word[1:]
+		case strings.HasPrefix(word, "@") && len(word) > 1 && task.Field(ContextField) == "":
+			task.SetField(ContextField, word[1:])
+		case todoTxtTag.MatchString(word):
+			key, value, _ := strings.Cut(word, ":")
+			if f.extended {
+				ok, err := parseExtendedTag(&extended, key, value)
+				if err != nil {
+					return models.Task{}, err
+				}
+				if ok {
+					continue
+				}
+			}
+			switch key {
+			case "due":
+				due, err := time.ParseInLocation(models.DateLayout, value, time.Local)
+				if err != nil {
+					return models.Task{}, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD", value)
+				}
+				task.Due = &due
+			case "milestone":
+				task.Milestone = value
+			case "estimate":
+				estimate, err := models.ParseEstimate(value)
+				if err != nil {
+					return models.Task{}, err
+				}
+				task.Estimate = &estimate
+			case "pri":
+				task.SetField(PriorityField, todoTxtPriorityName(value))
+			case "status":
+				if !f.workflow.IsValid(models.Status(value)) {
+					return models.Task{}, fmt.Errorf("unknown status %q", value)
+				}
+				status = models.Status(value)
+			default:
+				task.SetField(key, value)
+			}
+		default:
+			title = append(title, word)
+		}
+	}
+
+	task.Title = strings.Join(title, " ")
+	if task.Title == "" {
+		return models.Task{}, fmt.Errorf("task has no title: %q", line)
+	}
+
+	if f.workflow.IsClosed(status) && !completed.IsZero() && extended.History == nil {
+		task.SetStatus(status, completed)
+		task.UpdatedAt = completed
+	} else {
+		task.Status = status
+	}
+
+	if f.extended {
+		task.Description = extended.Description
+		task.Recurrence = extended.Recurrence
+		task.TimeEntries = extended.TimeEntries
+		task.History = extended.History
+		if !extended.CreatedAt.IsZero() {
+			task.CreatedAt = extended.CreatedAt
+			task.UpdatedAt = extended.CreatedAt
+		}
+		if !extended.UpdatedAt.IsZero() {
+			task.UpdatedAt = extended.UpdatedAt
+		}
+	}
+
+	return task, nil
+}
+
+// defaultStatus returns the status of lines without a status tag
+func (f *TodoTxt) defaultStatus(closed bool) models.Status {
+	if closed {
+		return statusFor(f.workflow, models.CategoryClosed)
+	}
+	return statusFor(f.workflow, models.CategoryOpen)
+}
+
+// todoTxtDate reports whether the first word is a YYYY-MM-DD date
+func todoTxtDate(words []string) (time.Time, bool) {
+	if len(words) == 0 {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(models.DateLayout, words[0], time.Local)
+	return date, err == nil
+}
+
+// isTodoTxtPriority reports whether word is a priority such as (A)
+func isTodoTxtPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && word[2] == ')' && word[1] >= 'A' && word[1] <= 'Z'
+}
+
+// todoTxtPriority maps a priority field value onto a todo.txt priority letter
+func todoTxtPriority(value string) string {
+	if len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z' {
+		return value
+	}
+
+	switch priority := icsPriority(value); {
+	case priority >= 1 && priority <= 4:
+		return "A"
+	case priority == 5:
+		return "B"
+	case priority >= 6:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// todoTxtPriorityName maps a todo.txt priority letter onto a priority field value
+func todoTxtPriorityName(letter string) string {
+	if name, ok := todoTxtPriorities[letter]; ok {
+		return name
+	}
+	return letter
+}
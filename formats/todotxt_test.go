@@ -0,0 +1,137 @@
+package formats
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestTodoTxtParseLine(t *testing.T) {
+	format := NewTodoTxt(models.DefaultWorkflow())
+
+	task, err := format.ParseLine("(A) 2024-03-01 Call mom at 10:30 +family @phone due:2024-03-10 https://example.com customer:Acme")
+	if err != nil {
+		t.Fatalf("ParseLine: unexpected error %v", err)
+	}
+
+	if task.Title != "Call mom at 10:30 https://example.com" {
+		t.Errorf("Expected tags to be removed from the title, got %q", task.Title)
+	}
+	if task.Label != "family" || task.Field(ContextField) != "phone" || task.Field("customer") != "Acme" {
+		t.Errorf("Expected project, context and tag to be mapped, got %+v", task)
+	}
+	if task.Field(PriorityField) != "high" {
+		t.Errorf("Expected priority high, got %q", task.Field(PriorityField))
+	}
+	if expected := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local); !task.CreatedAt.Equal(expected) {
+		t.Errorf("Expected created %s, got %s", expected, task.CreatedAt)
+	}
+	if task.Due == nil || task.Due.Format(models.DateLayout) != "2024-03-10" {
+		t.Errorf("Expected due 2024-03-10, got %v", task.Due)
+	}
+	if task.Status != models.StatusTodo {
+		t.Errorf("Expected status to-do, got %s", task.Status)
+	}
+
+	done, err := format.ParseLine("x 2024-03-05 2024-03-01 Ship it pri:B")
+	if err != nil {
+		t.Fatalf("ParseLine: unexpected error %v", err)
+	}
+
+	if done.Status != models.StatusDone || done.Field(PriorityField) != "medium" {
+		t.Errorf("Expected a done task with medium priority, got %+v", done)
+	}
+	completed, ok := done.CompletedAt(models.DefaultWorkflow())
+	if expected := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local); !ok || !completed.Equal(expected) {
+		t.Errorf("Expected completion at %s, got %s", expected, completed)
+	}
+
+	for _, line := range []string{"+project @context", "Task due:soon", "Task status:blocked"} {
+		if _, err := format.ParseLine(line); err == nil {
+			t.Errorf("Expected error for %q, got nil", line)
+		}
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	format := NewTodoTxt(models.DefaultWorkflow())
+
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+	estimate := models.Estimate{Duration: 90 * time.Minute}
+
+	active := models.Task{
+		Title:     "Write the report",
+		Label:     "work",
+		Status:    models.StatusInProgress,
+		CreatedAt: created,
+		UpdatedAt: created,
+		Due:       &due,
+		Estimate:  &estimate,
+		Milestone: "v1.0",
+	}
+	active.SetField(PriorityField, "low")
+	active.SetField("customer", "Acme Corp")
+
+	done := models.Task{Title: "Ship", Label: "release", Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created}
+	done.SetField(PriorityField, "1")
+	done.SetStatus(models.StatusDone, due)
+
+	if line := format.FormatLine(active); line != "(C) 2024-03-01 Write the report +work due:2024-03-15 milestone:v1.0 estimate:1h30m status:in-progress customer:Acme_Corp" {
+		t.Errorf("Unexpected line %q", line)
+	}
+	if line := format.FormatLine(done); line != "x 2024-03-15 2024-03-01 Ship +release pri:A" {
+		t.Errorf("Unexpected line %q", line)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, []models.Task{active, done}); err != nil {
+		t.Fatalf("Encode: unexpected error %v", err)
+	}
+
+	tasks, err := format.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode: unexpected error %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	got := tasks[0]
+	if got.Title != active.Title || got.Label != active.Label || got.Status != active.Status || got.Milestone != active.Milestone {
+		t.Errorf("Expected task to round trip, got %+v", got)
+	}
+	if got.Estimate == nil || *got.Estimate != estimate {
+		t.Errorf("Expected estimate %s, got %v", estimate, got.Estimate)
+	}
+	if got.Field(PriorityField) != "low" || got.Field("customer") != "Acme_Corp" {
+		t.Errorf("Expected fields to round trip, got %v", got.Fields)
+	}
+
+	if tasks[1].Status != models.StatusDone || tasks[1].Field(PriorityField) != "high" {
+		t.Errorf("Expected done task with high priority, got %+v", tasks[1])
+	}
+}
+
+func TestTodoTxtDecode(t *testing.T) {
+	input := "Buy milk @store\n\nTask due:tomorrow\n"
+
+	tasks, err := NewTodoTxt(models.DefaultWorkflow()).Decode(strings.NewReader(input))
+
+	var rowErrors RowErrors
+	if !errors.As(err, &rowErrors) || len(rowErrors) != 1 || rowErrors[0].Row != 3 {
+		t.Fatalf("Expected line 3 to fail, got %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].Title != "Buy milk" {
+		t.Fatalf("Expected one task, got %v", tasks)
+	}
+	if tasks[0].CreatedAt.IsZero() {
+		t.Error("Expected tasks without a creation date to be created now")
+	}
+}
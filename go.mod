@@ -1,3 +1,11 @@
 module github.com/mstgnz/cli-task-manager
 
 go 1.21
+
+require (
+	golang.org/x/crypto v0.33.0
+	golang.org/x/term v0.29.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.30.0 // indirect
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Estimate is the expected effort of a task, either as a duration or in story points
+type Estimate struct {
+	Duration time.Duration
+	Points   float64
+}
+
+// ParseEstimate parses a duration such as "2h30m" or story points such as
+// "3", "3pt" or "3sp"
+func ParseEstimate(value string) (Estimate, error) {
+	value = strings.TrimSpace(value)
+
+	points := strings.TrimSuffix(strings.TrimSuffix(value, "pt"), "sp")
+	if number, err := strconv.ParseFloat(points, 64); err == nil {
+		if number <= 0 {
+			return Estimate{}, fmt.Errorf("estimate must be positive, got %s", value)
+		}
+		return Estimate{Points: number}, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("invalid estimate %q, expected a duration like 2h30m or points like 3pt", value)
+	}
+	if duration <= 0 {
+		return Estimate{}, fmt.Errorf("estimate must be positive, got %s", value)
+	}
+
+	return Estimate{Duration: duration}, nil
+}
+
+// IsPoints reports whether the estimate is expressed in story points
+func (e Estimate) IsPoints() bool {
+	return e.Points > 0
+}
+
+// String returns the estimate in the format accepted by ParseEstimate
+func (e Estimate) String() string {
+	if e.IsPoints() {
+		return strconv.FormatFloat(e.Points, 'f', -1, 64) + "pt"
+	}
+
+	// Keep the exact duration so that estimates under a minute or with
+	// seconds survive a round trip, but drop trailing zero units.
+	value := e.Duration.String()
+	if strings.HasSuffix(value, "m0s") {
+		value = strings.TrimSuffix(value, "0s")
+	}
+	if strings.HasSuffix(value, "h0m") {
+		value = strings.TrimSuffix(value, "0m")
+	}
+	return value
+}
+
+// Remaining returns the estimated effort left after the tracked time.
+// It is negative when the task is running over and always zero for points.
+func (e Estimate) Remaining(tracked time.Duration) time.Duration {
+	if e.IsPoints() {
+		return 0
+	}
+	return e.Duration - tracked
+}
+
+// MarshalText stores the estimate in its human readable form
+func (e Estimate) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText parses an estimate stored by MarshalText
+func (e *Estimate) UnmarshalText(text []byte) error {
+	parsed, err := ParseEstimate(string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
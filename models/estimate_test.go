@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Estimate
+		wantErr  bool
+	}{
+		{"2h30m", Estimate{Duration: 150 * time.Minute}, false},
+		{"3", Estimate{Points: 3}, false},
+		{"5pt", Estimate{Points: 5}, false},
+		{"0.5sp", Estimate{Points: 0.5}, false},
+		{"0", Estimate{}, true},
+		{"-1h", Estimate{}, true},
+		{"soon", Estimate{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEstimate(%q): expected error=%v, got %v", tt.value, tt.wantErr, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseEstimate(%q): expected %+v, got %+v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestEstimateRemaining(t *testing.T) {
+	estimate := Estimate{Duration: 2 * time.Hour}
+
+	if remaining := estimate.Remaining(30 * time.Minute); remaining != 90*time.Minute {
+		t.Errorf("Expected 1h30m remaining, got %s", remaining)
+	}
+
+	if remaining := estimate.Remaining(3 * time.Hour); remaining != -time.Hour {
+		t.Errorf("Expected -1h remaining, got %s", remaining)
+	}
+
+	if remaining := (Estimate{Points: 3}).Remaining(time.Hour); remaining != 0 {
+		t.Errorf("Expected no remaining time for points, got %s", remaining)
+	}
+}
+
+func TestEstimateJSON(t *testing.T) {
+	task := NewTask("Test Task", "test")
+	task.Estimate = &Estimate{Duration: 90 * time.Minute}
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Failed to marshal task: %v", err)
+	}
+
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal task: %v", err)
+	}
+
+	if decoded.Estimate == nil || *decoded.Estimate != *task.Estimate {
+		t.Errorf("Expected estimate %s, got %v", task.Estimate, decoded.Estimate)
+	}
+}
+
+func TestEstimateTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		estimate Estimate
+		expected string
+	}{
+		{Estimate{Duration: 20 * time.Second}, "20s"},
+		{Estimate{Duration: time.Hour + 30*time.Minute + 20*time.Second}, "1h30m20s"},
+		{Estimate{Duration: 90 * time.Minute}, "1h30m"},
+		{Estimate{Duration: 2 * time.Hour}, "2h"},
+		{Estimate{Points: 2.5}, "2.5pt"},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.estimate.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%+v): %v", tt.estimate, err)
+		}
+		if string(text) != tt.expected {
+			t.Errorf("MarshalText(%+v): expected %q, got %q", tt.estimate, tt.expected, text)
+		}
+
+		var decoded Estimate
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+		if decoded != tt.estimate {
+			t.Errorf("UnmarshalText(%q): expected %+v, got %+v", text, tt.estimate, decoded)
+		}
+	}
+}
+
+func TestTaskIsOverEstimate(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	task := NewTask("Test Task", "test")
+	task.Estimate = &Estimate{Duration: time.Hour}
+	task.LogTime(start, 30*time.Minute)
+
+	if task.IsOverEstimate(start) {
+		t.Error("Expected task to be within its estimate")
+	}
+
+	task.LogTime(start, time.Hour)
+	if !task.IsOverEstimate(start) {
+		t.Error("Expected task to be over its estimate")
+	}
+
+	task.Estimate = &Estimate{Points: 1}
+	if task.IsOverEstimate(start) {
+		t.Error("Expected point estimates never to be over")
+	}
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the type of a custom field
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldDate   FieldType = "date"
+	FieldEnum   FieldType = "enum"
+)
+
+// DateLayout is the layout used for dates entered on the command line
+const DateLayout = "2006-01-02"
+
+// FieldDefinition declares a custom field and its type
+type FieldDefinition struct {
+	Type   FieldType `json:"type"`
+	Values []string  `json:"values,omitempty"`
+}
+
+// Validate checks that the definition is consistent
+func (d FieldDefinition) Validate() error {
+	switch d.Type {
+	case FieldString, FieldNumber, FieldDate:
+		return nil
+	case FieldEnum:
+		if len(d.Values) == 0 {
+			return fmt.Errorf("enum field must declare its values")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown field type: %s", d.Type)
+	}
+}
+
+// Normalize validates value against the field type and returns its
+// canonical representation
+func (d FieldDefinition) Normalize(value string) (string, error) {
+	switch d.Type {
+	case FieldNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case FieldDate:
+		date, err := time.Parse(DateLayout, value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a date (expected YYYY-MM-DD)", value)
+		}
+		return date.Format(DateLayout), nil
+	case FieldEnum:
+		for _, allowed := range d.Values {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%q is not one of %s", value, strings.Join(d.Values, ", "))
+	default:
+		return value, nil
+	}
+}
+
+// Compare orders two normalized values of the field, returning a negative
+// number, zero or a positive number. Empty values sort last.
+func (d FieldDefinition) Compare(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	switch d.Type {
+	case FieldNumber:
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case FieldEnum:
+		// Enum values sort in their declared order
+		return d.index(a) - d.index(b)
+	default:
+		// Strings and YYYY-MM-DD dates sort lexically
+		return strings.Compare(a, b)
+	}
+}
+
+// index returns the position of value in the declared enum values
+func (d FieldDefinition) index(value string) int {
+	for i, allowed := range d.Values {
+		if allowed == value {
+			return i
+		}
+	}
+	return len(d.Values)
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestFieldDefinitionNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		def      FieldDefinition
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"String", FieldDefinition{Type: FieldString}, "Acme", "Acme", false},
+		{"Number", FieldDefinition{Type: FieldNumber}, "3.50", "3.5", false},
+		{"Invalid number", FieldDefinition{Type: FieldNumber}, "three", "", true},
+		{"Date", FieldDefinition{Type: FieldDate}, "2024-03-01", "2024-03-01", false},
+		{"Invalid date", FieldDefinition{Type: FieldDate}, "01/03/2024", "", true},
+		{"Enum", FieldDefinition{Type: FieldEnum, Values: []string{"api", "ui"}}, "ui", "ui", false},
+		{"Invalid enum", FieldDefinition{Type: FieldEnum, Values: []string{"api", "ui"}}, "db", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.def.Normalize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFieldDefinitionValidate(t *testing.T) {
+	if err := (FieldDefinition{Type: FieldNumber}).Validate(); err != nil {
+		t.Errorf("Expected number field to be valid, got %v", err)
+	}
+
+	if err := (FieldDefinition{Type: FieldEnum}).Validate(); err == nil {
+		t.Error("Expected error for enum without values, got nil")
+	}
+
+	if err := (FieldDefinition{Type: "list"}).Validate(); err == nil {
+		t.Error("Expected error for unknown type, got nil")
+	}
+}
+
+func TestFieldDefinitionCompare(t *testing.T) {
+	number := FieldDefinition{Type: FieldNumber}
+	if number.Compare("2", "10") >= 0 {
+		t.Error("Expected 2 to sort before 10 numerically")
+	}
+
+	if number.Compare("", "1") <= 0 {
+		t.Error("Expected empty values to sort last")
+	}
+
+	enum := FieldDefinition{Type: FieldEnum, Values: []string{"low", "high"}}
+	if enum.Compare("low", "high") >= 0 {
+		t.Error("Expected enum values to sort in declared order")
+	}
+
+	date := FieldDefinition{Type: FieldDate}
+	if date.Compare("2024-01-02", "2023-12-31") <= 0 {
+		t.Error("Expected later dates to sort after earlier ones")
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// StatusChange records a status transition of a task
+type StatusChange struct {
+	From Status    `json:"from" yaml:"from"`
+	To   Status    `json:"to" yaml:"to"`
+	At   time.Time `json:"at" yaml:"at"`
+}
+
+// SetStatus changes the status of the task and records the transition
+func (t *Task) SetStatus(status Status, at time.Time) {
+	if status == t.Status {
+		return
+	}
+	t.History = append(t.History, StatusChange{From: t.Status, To: status, At: at})
+	t.Status = status
+}
+
+// StatusAt returns the status the task had at the given time. Without a
+// recorded history the current status is assumed to date from UpdatedAt and
+// an empty status is returned for earlier times.
+func (t Task) StatusAt(at time.Time) Status {
+	if len(t.History) == 0 {
+		if at.Before(t.UpdatedAt) {
+			return ""
+		}
+		return t.Status
+	}
+
+	status := t.History[0].From
+	for _, change := range t.History {
+		if change.At.After(at) {
+			break
+		}
+		status = change.To
+	}
+	return status
+}
+
+// IsOpenAt reports whether the task existed and was not closed at the given time
+func (t Task) IsOpenAt(at time.Time, workflow *Workflow) bool {
+	if t.CreatedAt.After(at) {
+		return false
+	}
+	return !workflow.IsClosed(t.StatusAt(at))
+}
+
+// CompletedAt returns when a closed task was last closed, falling back to
+// UpdatedAt for tasks closed before status history was recorded
+func (t Task) CompletedAt(workflow *Workflow) (time.Time, bool) {
+	if !workflow.IsClosed(t.Status) {
+		return time.Time{}, false
+	}
+
+	for i := len(t.History) - 1; i >= 0; i-- {
+		change := t.History[i]
+		if workflow.IsClosed(change.To) && !workflow.IsClosed(change.From) {
+			return change.At, true
+		}
+	}
+
+	return t.UpdatedAt, true
+}
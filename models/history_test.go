@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskSetStatus(t *testing.T) {
+	task := NewTask("Test Task", "test")
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	task.SetStatus(StatusInProgress, at)
+	task.SetStatus(StatusInProgress, at.Add(time.Hour))
+	task.SetStatus(StatusDone, at.Add(2*time.Hour))
+
+	if len(task.History) != 2 {
+		t.Fatalf("Expected 2 recorded changes, got %d", len(task.History))
+	}
+
+	if task.History[0].From != StatusTodo || task.History[1].To != StatusDone {
+		t.Errorf("Unexpected history: %+v", task.History)
+	}
+}
+
+func TestTaskStatusAt(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	task := Task{Status: StatusTodo, CreatedAt: created, UpdatedAt: created}
+	task.SetStatus(StatusInProgress, created.Add(24*time.Hour))
+	task.SetStatus(StatusDone, created.Add(48*time.Hour))
+
+	tests := []struct {
+		at       time.Time
+		expected Status
+	}{
+		{created, StatusTodo},
+		{created.Add(30 * time.Hour), StatusInProgress},
+		{created.Add(72 * time.Hour), StatusDone},
+	}
+
+	for _, tt := range tests {
+		if got := task.StatusAt(tt.at); got != tt.expected {
+			t.Errorf("At %s expected %s, got %s", tt.at, tt.expected, got)
+		}
+	}
+
+	workflow := DefaultWorkflow()
+	if task.IsOpenAt(created.Add(-time.Hour), workflow) {
+		t.Error("Expected task not to exist before its creation")
+	}
+	if !task.IsOpenAt(created.Add(30*time.Hour), workflow) {
+		t.Error("Expected task to be open while in progress")
+	}
+	if task.IsOpenAt(created.Add(72*time.Hour), workflow) {
+		t.Error("Expected task to be closed after completion")
+	}
+
+	// Without history the status is assumed to date from UpdatedAt
+	legacy := Task{Status: StatusDone, CreatedAt: created, UpdatedAt: created.Add(24 * time.Hour)}
+	if !legacy.IsOpenAt(created.Add(time.Hour), workflow) {
+		t.Error("Expected legacy task to be open before its last update")
+	}
+	if legacy.IsOpenAt(created.Add(48*time.Hour), workflow) {
+		t.Error("Expected legacy task to be closed after its last update")
+	}
+}
+
+func TestTaskCompletedAt(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	workflow := DefaultWorkflow()
+
+	task := Task{Status: StatusTodo, CreatedAt: created, UpdatedAt: created}
+	if _, ok := task.CompletedAt(workflow); ok {
+		t.Error("Expected open task not to be completed")
+	}
+
+	task.SetStatus(StatusDone, created.Add(time.Hour))
+	task.SetStatus(StatusTodo, created.Add(2*time.Hour))
+	task.SetStatus(StatusDone, created.Add(3*time.Hour))
+
+	completed, ok := task.CompletedAt(workflow)
+	if !ok || !completed.Equal(created.Add(3*time.Hour)) {
+		t.Errorf("Expected last completion time, got %v", completed)
+	}
+
+	legacy := Task{Status: StatusDone, CreatedAt: created, UpdatedAt: created.Add(time.Hour)}
+	completed, ok = legacy.CompletedAt(workflow)
+	if !ok || !completed.Equal(legacy.UpdatedAt) {
+		t.Errorf("Expected UpdatedAt for legacy task, got %v", completed)
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Milestone groups tasks planned for a release or sprint
+type Milestone struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Start       *time.Time `json:"start,omitempty"`
+	End         *time.Time `json:"end,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// String returns a formatted string representation of the milestone
+func (m Milestone) String() string {
+	return fmt.Sprintf("%s (%s - %s)", m.Name, formatOptionalDate(m.Start), formatOptionalDate(m.End))
+}
+
+// Progress summarises the completion of the tasks assigned to a milestone
+type Progress struct {
+	Total  int
+	Closed int
+	Open   []Task
+}
+
+// Percent returns the share of closed tasks, or 0 without tasks
+func (p Progress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Closed) * 100 / float64(p.Total)
+}
+
+// MilestoneProgress computes the progress of the named milestone using the
+// workflow to decide which tasks are closed
+func MilestoneProgress(name string, tasks []Task, workflow *Workflow) Progress {
+	var progress Progress
+	for _, task := range tasks {
+		if task.Milestone != name {
+			continue
+		}
+		progress.Total++
+		if workflow.IsClosed(task.Status) {
+			progress.Closed++
+		} else {
+			progress.Open = append(progress.Open, task)
+		}
+	}
+	return progress
+}
+
+// formatOptionalDate formats a date, or returns "?" when unset
+func formatOptionalDate(t *time.Time) string {
+	if t == nil {
+		return "?"
+	}
+	return t.Format(DateLayout)
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestMilestoneString(t *testing.T) {
+	start := date(2024, 3, 1)
+	milestone := Milestone{Name: "sprint-1", Start: &start}
+
+	expected := "sprint-1 (2024-03-01 - ?)"
+	if milestone.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, milestone.String())
+	}
+}
+
+func TestMilestoneProgress(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, Status: StatusDone, Milestone: "sprint-1"},
+		{ID: 2, Status: StatusInProgress, Milestone: "sprint-1"},
+		{ID: 3, Status: StatusTodo, Milestone: "sprint-1"},
+		{ID: 4, Status: StatusDone, Milestone: "sprint-1"},
+		{ID: 5, Status: StatusTodo},
+	}
+
+	progress := MilestoneProgress("sprint-1", tasks, DefaultWorkflow())
+
+	if progress.Total != 4 || progress.Closed != 2 || len(progress.Open) != 2 {
+		t.Errorf("Unexpected progress: %+v", progress)
+	}
+
+	if progress.Percent() != 50 {
+		t.Errorf("Expected 50%%, got %.0f%%", progress.Percent())
+	}
+
+	if empty := MilestoneProgress("sprint-2", tasks, DefaultWorkflow()); empty.Percent() != 0 {
+		t.Errorf("Expected 0%% for an empty milestone, got %.0f%%", empty.Percent())
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence kinds
+const (
+	RecurDaily   = "daily"
+	RecurWeekly  = "weekly"
+	RecurMonthly = "monthly"
+	RecurEvery   = "every"
+)
+
+// Recurrence describes when the next occurrence of a recurring task is due.
+// Rules are written as:
+//
+//	daily           every day
+//	weekly:mon,thu  on the given weekdays ("weekly" alone keeps the weekday)
+//	monthly:15      on the given day of the month ("monthly" alone keeps the day)
+//	every:3d        the given number of days after completion
+type Recurrence struct {
+	Kind     string
+	Weekdays []time.Weekday
+	Day      int
+	Days     int
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseRecurrence parses a recurrence rule
+func ParseRecurrence(rule string) (Recurrence, error) {
+	kind, arg, _ := strings.Cut(strings.ToLower(strings.TrimSpace(rule)), ":")
+	r := Recurrence{Kind: kind}
+
+	switch kind {
+	case RecurDaily:
+		if arg != "" {
+			return Recurrence{}, fmt.Errorf("daily recurrence takes no argument: %s", rule)
+		}
+	case RecurWeekly:
+		if arg == "" {
+			break
+		}
+		for _, name := range strings.Split(arg, ",") {
+			day, ok := weekdays[strings.TrimSpace(name)]
+			if !ok {
+				return Recurrence{}, fmt.Errorf("invalid weekday %q in %s", name, rule)
+			}
+			r.Weekdays = append(r.Weekdays, day)
+		}
+	case RecurMonthly:
+		if arg == "" {
+			break
+		}
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < 1 || day > 31 {
+			return Recurrence{}, fmt.Errorf("invalid day of month %q in %s", arg, rule)
+		}
+		r.Day = day
+	case RecurEvery:
+		days, err := strconv.Atoi(strings.TrimSuffix(arg, "d"))
+		if err != nil || days < 1 {
+			return Recurrence{}, fmt.Errorf("invalid interval %q in %s, expected e.g. every:3d", arg, rule)
+		}
+		r.Days = days
+	default:
+		return Recurrence{}, fmt.Errorf("unknown recurrence %q, expected daily, weekly, monthly or every", rule)
+	}
+
+	return r, nil
+}
+
+// Next returns the due date of the occurrence following one due on due and
+// completed on completed. Scheduled rules count from the previous due date
+// (or the completion date when there was none); "every" counts from completion.
+func (r Recurrence) Next(due *time.Time, completed time.Time) time.Time {
+	base := startOfDay(completed)
+	if due != nil && r.Kind != RecurEvery {
+		base = startOfDay(*due)
+	}
+
+	switch r.Kind {
+	case RecurWeekly:
+		days := r.Weekdays
+		if len(days) == 0 {
+			days = []time.Weekday{base.Weekday()}
+		}
+		for next := base.AddDate(0, 0, 1); ; next = next.AddDate(0, 0, 1) {
+			for _, day := range days {
+				if next.Weekday() == day {
+					return next
+				}
+			}
+		}
+	case RecurMonthly:
+		day := r.Day
+		if day == 0 {
+			day = base.Day()
+		}
+		// Use this month's day if still ahead, otherwise next month's
+		if next := dayOfMonth(base.Year(), base.Month(), day, base.Location()); next.After(base) {
+			return next
+		}
+		return dayOfMonth(base.Year(), base.Month()+1, day, base.Location())
+	case RecurEvery:
+		return base.AddDate(0, 0, r.Days)
+	default:
+		return base.AddDate(0, 0, 1)
+	}
+}
+
+// startOfDay truncates t to midnight in its location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// dayOfMonth returns the given day of a month, clamped to the month's last day
+func dayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	valid := []string{"daily", "weekly", "weekly:mon,thu", "monthly", "monthly:31", "every:3d", "every:10"}
+	for _, rule := range valid {
+		if _, err := ParseRecurrence(rule); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", rule, err)
+		}
+	}
+
+	invalid := []string{"", "hourly", "daily:2", "weekly:funday", "monthly:32", "every:0d", "every:soon"}
+	for _, rule := range invalid {
+		if _, err := ParseRecurrence(rule); err == nil {
+			t.Errorf("Expected %q to be invalid, got nil", rule)
+		}
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2024-03-01 is a Friday
+	due := date(2024, 3, 1)
+	completed := time.Date(2024, 3, 5, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		due      *time.Time
+		expected time.Time
+	}{
+		{"daily", &due, date(2024, 3, 2)},
+		{"weekly", &due, date(2024, 3, 8)},
+		{"weekly:mon,thu", &due, date(2024, 3, 4)},
+		{"monthly", &due, date(2024, 4, 1)},
+		{"monthly:15", &due, date(2024, 3, 15)},
+		{"every:3d", &due, date(2024, 3, 8)},
+		{"daily", nil, date(2024, 3, 6)},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRecurrence(tt.rule)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.rule, err)
+		}
+		if got := rule.Next(tt.due, completed); !got.Equal(tt.expected) {
+			t.Errorf("%s: expected %s, got %s", tt.rule, tt.expected.Format(DateLayout), got.Format(DateLayout))
+		}
+	}
+
+	// Days past the end of a month are clamped
+	rule, _ := ParseRecurrence("monthly:31")
+	jan := date(2024, 1, 31)
+	if got := rule.Next(&jan, completed); !got.Equal(date(2024, 2, 29)) {
+		t.Errorf("Expected 2024-02-29, got %s", got.Format(DateLayout))
+	}
+}
+
+func TestTaskNextOccurrence(t *testing.T) {
+	due := date(2024, 3, 1)
+
+	task := NewTask("Rotate credentials", "ops")
+	task.ID = 7
+	task.Status = StatusDone
+	task.Due = &due
+	task.Recurrence = "monthly"
+	task.SetField("customer", "Acme")
+	task.LogTime(due, time.Hour)
+
+	next, err := task.NextOccurrence(StatusTodo, due)
+	if err != nil {
+		t.Fatalf("Failed to create next occurrence: %v", err)
+	}
+
+	if next.ID != 0 || next.Status != StatusTodo || len(next.TimeEntries) != 0 {
+		t.Errorf("Expected a fresh task, got %+v", next)
+	}
+
+	if next.Due == nil || !next.Due.Equal(date(2024, 4, 1)) {
+		t.Errorf("Expected next occurrence due 2024-04-01, got %v", next.Due)
+	}
+
+	if next.Recurrence != task.Recurrence || next.Field("customer") != "Acme" {
+		t.Errorf("Expected recurrence and fields to be copied, got %+v", next)
+	}
+
+	task.Recurrence = "never"
+	if _, err := task.NextOccurrence(StatusTodo, due); err == nil {
+		t.Error("Expected error for invalid recurrence, got nil")
+	}
+}
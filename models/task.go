@@ -16,13 +16,20 @@ const (
 
 // Task represents a single task in the task manager
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description,omitempty"`
-	Status      Status    `json:"status"`
-	Label       string    `json:"label"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int               `json:"id" yaml:"id,omitempty"`
+	Title       string            `json:"title" yaml:"title,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Status      Status            `json:"status" yaml:"status"`
+	Label       string            `json:"label" yaml:"label"`
+	CreatedAt   time.Time         `json:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" yaml:"updated_at"`
+	Fields      map[string]string `json:"fields,omitempty" yaml:"fields,omitempty"`
+	TimeEntries []TimeEntry       `json:"time_entries,omitempty" yaml:"time_entries,omitempty"`
+	Estimate    *Estimate         `json:"estimate,omitempty" yaml:"estimate,omitempty"`
+	Due         *time.Time        `json:"due,omitempty" yaml:"due,omitempty"`
+	Recurrence  string            `json:"recurrence,omitempty" yaml:"recurrence,omitempty"`
+	Milestone   string            `json:"milestone,omitempty" yaml:"milestone,omitempty"`
+	History     []StatusChange    `json:"history,omitempty" yaml:"history,omitempty"`
 }
 
 // String returns a formatted string representation of the task
@@ -30,6 +37,57 @@ func (t Task) String() string {
 	return fmt.Sprintf("%d. [%s] %s [Status: %s]", t.ID, t.Label, t.Title, t.Status)
 }
 
+// Field returns the value of a custom field, or an empty string if unset
+func (t Task) Field(name string) string {
+	return t.Fields[name]
+}
+
+// SetField sets a custom field, removing it when value is empty
+func (t *Task) SetField(name, value string) {
+	if value == "" {
+		delete(t.Fields, name)
+		return
+	}
+	if t.Fields == nil {
+		t.Fields = make(map[string]string)
+	}
+	t.Fields[name] = value
+}
+
+// IsOverEstimate reports whether the tracked time exceeds a duration estimate
+func (t Task) IsOverEstimate(now time.Time) bool {
+	if t.Estimate == nil || t.Estimate.IsPoints() {
+		return false
+	}
+	return t.TrackedTime(now) > t.Estimate.Duration
+}
+
+// NextOccurrence returns a copy of a recurring task for its next occurrence,
+// due according to its recurrence rule after being completed at the given time
+func (t Task) NextOccurrence(status Status, completed time.Time) (Task, error) {
+	rule, err := ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return Task{}, err
+	}
+
+	due := rule.Next(t.Due, completed)
+
+	next := NewTask(t.Title, t.Label)
+	next.Description = t.Description
+	next.Status = status
+	next.Recurrence = t.Recurrence
+	next.Due = &due
+	if t.Estimate != nil {
+		estimate := *t.Estimate
+		next.Estimate = &estimate
+	}
+	for name, value := range t.Fields {
+		next.SetField(name, value)
+	}
+
+	return next, nil
+}
+
 // NewTask creates a new task with the given title and label
 func NewTask(title, label string) Task {
 	now := time.Now()
@@ -47,3 +47,21 @@ func TestTaskString(t *testing.T) {
 		t.Errorf("Expected string representation to be %s, got %s", expected, task.String())
 	}
 }
+
+func TestTaskFields(t *testing.T) {
+	task := NewTask("Test Task", "test")
+
+	if task.Field("customer") != "" {
+		t.Errorf("Expected unset field to be empty, got %s", task.Field("customer"))
+	}
+
+	task.SetField("customer", "Acme")
+	if task.Field("customer") != "Acme" {
+		t.Errorf("Expected customer to be Acme, got %s", task.Field("customer"))
+	}
+
+	task.SetField("customer", "")
+	if _, ok := task.Fields["customer"]; ok {
+		t.Error("Expected empty value to remove the field")
+	}
+}
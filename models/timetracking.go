@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeEntry is a work session recorded against a task
+type TimeEntry struct {
+	Start time.Time  `json:"start" yaml:"start"`
+	End   *time.Time `json:"end,omitempty" yaml:"end,omitempty"`
+}
+
+// Running reports whether the session is still in progress
+func (e TimeEntry) Running() bool {
+	return e.End == nil
+}
+
+// Duration returns the length of the session, measuring running sessions up to now
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.End == nil {
+		return now.Sub(e.Start)
+	}
+	return e.End.Sub(e.Start)
+}
+
+// Running reports whether a timer is running on the task
+func (t Task) Running() bool {
+	for _, entry := range t.TimeEntries {
+		if entry.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+// StartTimer opens a new work session at the given time
+func (t *Task) StartTimer(now time.Time) error {
+	if t.Running() {
+		return errors.New("timer is already running")
+	}
+	t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: now})
+	return nil
+}
+
+// StopTimer closes the running work session and returns its duration
+func (t *Task) StopTimer(now time.Time) (time.Duration, error) {
+	for i, entry := range t.TimeEntries {
+		if entry.Running() {
+			end := now
+			t.TimeEntries[i].End = &end
+			return t.TimeEntries[i].Duration(now), nil
+		}
+	}
+	return 0, errors.New("no timer is running")
+}
+
+// LogTime records a finished work session of the given duration starting at start
+func (t *Task) LogTime(start time.Time, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", duration)
+	}
+	end := start.Add(duration)
+	t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: start, End: &end})
+	return nil
+}
+
+// TrackedTime returns the total time recorded against the task
+func (t Task) TrackedTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		total += entry.Duration(now)
+	}
+	return total
+}
+
+// FormatDuration formats a duration rounded to the minute, e.g. "1h30m"
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskTimer(t *testing.T) {
+	task := NewTask("Test Task", "test")
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := task.StartTimer(start); err != nil {
+		t.Fatalf("Failed to start timer: %v", err)
+	}
+
+	if !task.Running() {
+		t.Error("Expected timer to be running")
+	}
+
+	if err := task.StartTimer(start); err == nil {
+		t.Error("Expected error when starting a running timer, got nil")
+	}
+
+	// Running sessions count up to now
+	if tracked := task.TrackedTime(start.Add(10 * time.Minute)); tracked != 10*time.Minute {
+		t.Errorf("Expected 10m tracked, got %s", tracked)
+	}
+
+	duration, err := task.StopTimer(start.Add(90 * time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to stop timer: %v", err)
+	}
+
+	if duration != 90*time.Minute {
+		t.Errorf("Expected 1h30m session, got %s", duration)
+	}
+
+	if _, err := task.StopTimer(start); err == nil {
+		t.Error("Expected error when stopping without a running timer, got nil")
+	}
+
+	if err := task.LogTime(start, 30*time.Minute); err != nil {
+		t.Fatalf("Failed to log time: %v", err)
+	}
+
+	if err := task.LogTime(start, -time.Minute); err == nil {
+		t.Error("Expected error when logging a negative duration, got nil")
+	}
+
+	if tracked := task.TrackedTime(time.Now()); tracked != 2*time.Hour {
+		t.Errorf("Expected 2h tracked, got %s", tracked)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "0m"},
+		{45 * time.Minute, "45m"},
+		{2 * time.Hour, "2h"},
+		{90*time.Minute + 20*time.Second, "1h30m"},
+		{-30 * time.Minute, "-30m"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.duration); got != tt.expected {
+			t.Errorf("Expected %s for %v, got %s", tt.expected, tt.duration, got)
+		}
+	}
+}
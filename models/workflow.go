@@ -0,0 +1,143 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Category groups statuses so that reports work with any workflow
+type Category string
+
+const (
+	CategoryOpen   Category = "open"
+	CategoryActive Category = "active"
+	CategoryClosed Category = "closed"
+)
+
+// StatusDefinition declares a status and its category
+type StatusDefinition struct {
+	Name     Status   `json:"name"`
+	Category Category `json:"category"`
+}
+
+// Workflow declares the available statuses and the allowed transitions
+// between them. An empty transition graph allows every transition.
+type Workflow struct {
+	Statuses    []StatusDefinition  `json:"statuses"`
+	Transitions map[Status][]Status `json:"transitions,omitempty"`
+}
+
+// DefaultWorkflow returns the built-in to-do/in-progress/done workflow
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: []StatusDefinition{
+			{Name: StatusTodo, Category: CategoryOpen},
+			{Name: StatusInProgress, Category: CategoryActive},
+			{Name: StatusDone, Category: CategoryClosed},
+		},
+	}
+}
+
+// Validate checks that the workflow is consistent
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("workflow must declare at least one status")
+	}
+
+	seen := make(map[Status]bool)
+	for _, def := range w.Statuses {
+		if def.Name == "" {
+			return errors.New("workflow status name cannot be empty")
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("duplicate workflow status: %s", def.Name)
+		}
+		seen[def.Name] = true
+
+		switch def.Category {
+		case CategoryOpen, CategoryActive, CategoryClosed:
+		default:
+			return fmt.Errorf("invalid category %q for status %s", def.Category, def.Name)
+		}
+	}
+
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("transition from unknown status: %s", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("transition from %s to unknown status: %s", from, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsValid reports whether the status is declared by the workflow
+func (w *Workflow) IsValid(status Status) bool {
+	_, ok := w.definition(status)
+	return ok
+}
+
+// Category returns the category of the status, or an empty category if the
+// status is unknown
+func (w *Workflow) Category(status Status) Category {
+	def, _ := w.definition(status)
+	return def.Category
+}
+
+// IsClosed reports whether the status belongs to the closed category
+func (w *Workflow) IsClosed(status Status) bool {
+	return w.Category(status) == CategoryClosed
+}
+
+// CanTransition reports whether a task may move from one status to another
+func (w *Workflow) CanTransition(from, to Status) bool {
+	if from == to || len(w.Transitions) == 0 {
+		return true
+	}
+
+	for _, target := range w.Transitions[from] {
+		if target == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InitialStatus returns the status assigned to new tasks
+func (w *Workflow) InitialStatus() Status {
+	return w.Statuses[0].Name
+}
+
+// FirstInCategory returns the first declared status of the given category
+func (w *Workflow) FirstInCategory(category Category) (Status, bool) {
+	for _, def := range w.Statuses {
+		if def.Category == category {
+			return def.Name, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the declared statuses in order
+func (w *Workflow) Names() []Status {
+	names := make([]Status, 0, len(w.Statuses))
+	for _, def := range w.Statuses {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// definition returns the declaration of the status
+func (w *Workflow) definition(status Status) (StatusDefinition, bool) {
+	for _, def := range w.Statuses {
+		if def.Name == status {
+			return def, true
+		}
+	}
+	return StatusDefinition{}, false
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+)
+
+func teamWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: []StatusDefinition{
+			{Name: StatusTodo, Category: CategoryOpen},
+			{Name: StatusInProgress, Category: CategoryActive},
+			{Name: "review", Category: CategoryActive},
+			{Name: "blocked", Category: CategoryOpen},
+			{Name: StatusDone, Category: CategoryClosed},
+			{Name: "wontfix", Category: CategoryClosed},
+		},
+		Transitions: map[Status][]Status{
+			StatusTodo:       {StatusInProgress, "wontfix"},
+			StatusInProgress: {"review", "blocked"},
+			"review":         {StatusInProgress, StatusDone},
+			"blocked":        {StatusInProgress},
+		},
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	workflow := DefaultWorkflow()
+
+	if err := workflow.Validate(); err != nil {
+		t.Fatalf("Expected default workflow to be valid, got %v", err)
+	}
+
+	if workflow.InitialStatus() != StatusTodo {
+		t.Errorf("Expected initial status to be %s, got %s", StatusTodo, workflow.InitialStatus())
+	}
+
+	if !workflow.CanTransition(StatusDone, StatusTodo) {
+		t.Error("Expected default workflow to allow every transition")
+	}
+
+	if workflow.IsValid("review") {
+		t.Error("Expected review to be invalid in the default workflow")
+	}
+}
+
+func TestWorkflowTransitions(t *testing.T) {
+	workflow := teamWorkflow()
+
+	if err := workflow.Validate(); err != nil {
+		t.Fatalf("Expected workflow to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		from, to Status
+		allowed  bool
+	}{
+		{StatusTodo, StatusInProgress, true},
+		{StatusTodo, StatusDone, false},
+		{StatusInProgress, "review", true},
+		{"review", StatusDone, true},
+		{StatusDone, StatusTodo, false},
+		{"blocked", "blocked", true},
+	}
+
+	for _, tt := range tests {
+		if got := workflow.CanTransition(tt.from, tt.to); got != tt.allowed {
+			t.Errorf("Expected transition %s -> %s allowed=%v, got %v", tt.from, tt.to, tt.allowed, got)
+		}
+	}
+}
+
+func TestWorkflowCategories(t *testing.T) {
+	workflow := teamWorkflow()
+
+	if workflow.Category("review") != CategoryActive {
+		t.Errorf("Expected review to be active, got %s", workflow.Category("review"))
+	}
+
+	if !workflow.IsClosed("wontfix") {
+		t.Error("Expected wontfix to be closed")
+	}
+
+	if workflow.Category("unknown") != "" {
+		t.Errorf("Expected unknown status to have no category, got %s", workflow.Category("unknown"))
+	}
+
+	status, ok := workflow.FirstInCategory(CategoryActive)
+	if !ok || status != StatusInProgress {
+		t.Errorf("Expected first active status to be %s, got %s", StatusInProgress, status)
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow Workflow
+	}{
+		{"No statuses", Workflow{}},
+		{"Duplicate status", Workflow{Statuses: []StatusDefinition{
+			{Name: StatusTodo, Category: CategoryOpen},
+			{Name: StatusTodo, Category: CategoryClosed},
+		}}},
+		{"Invalid category", Workflow{Statuses: []StatusDefinition{
+			{Name: StatusTodo, Category: "pending"},
+		}}},
+		{"Unknown transition target", Workflow{
+			Statuses:    []StatusDefinition{{Name: StatusTodo, Category: CategoryOpen}},
+			Transitions: map[Status][]Status{StatusTodo: {"review"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.workflow.Validate(); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// backupTimeLayout is the timestamp embedded in backup names
+const backupTimeLayout = "20060102-150405.000000"
+
+// BackupPolicy controls which backups of a task store are kept
+type BackupPolicy struct {
+	// Count is the number of backups kept, 0 disables automatic backups
+	Count int
+	// MaxAge removes backups older than it, 0 keeps backups of any age
+	MaxAge time.Duration
+}
+
+// Backup is a copy of a task store taken at a point in time, kept in a
+// directory of its own along with copies of the store's related files
+type Backup struct {
+	Name string
+	// Path is the copy of the task store
+	Path string
+	// Related are the copies of the related files that existed when the
+	// backup was taken
+	Related []string
+	Time    time.Time
+}
+
+// Backups manages the timestamped backups of a task store and of its related
+// files, such as its milestones, kept together in dir
+type Backups struct {
+	storePath string
+	related   []string
+	dir       string
+	policy    BackupPolicy
+}
+
+// NewBackups creates the backup manager of the task store at storePath,
+// which may be a file or, for the markdown backend, a directory, keeping the
+// backups in dir. The related files are backed up and restored with the store.
+func NewBackups(storePath, dir string, policy BackupPolicy, related ...string) *Backups {
+	return &Backups{
+		storePath: storePath,
+		related:   related,
+		dir:       dir,
+		policy:    policy,
+	}
+}
+
+// Dir returns the directory holding the backups
+func (b *Backups) Dir() string {
+	return b.dir
+}
+
+// Create copies the task store and its related files into a new backup and
+// removes the backups the policy no longer keeps
+func (b *Backups) Create() (Backup, error) {
+	if _, err := os.Stat(b.storePath); err != nil {
+		return Backup{}, fmt.Errorf("failed to read task store: %w", err)
+	}
+
+	now := time.Now()
+	backup := b.backup(b.stem()+"-"+now.Format(backupTimeLayout), now)
+	if err := os.MkdirAll(filepath.Dir(backup.Path), 0755); err != nil {
+		return Backup{}, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	if err := copyFiles(backup.Path, b.storePath, b.backupRelated(backup), b.related); err != nil {
+		os.RemoveAll(filepath.Dir(backup.Path))
+		return Backup{}, fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if err := b.rotate(now); err != nil {
+		return Backup{}, err
+	}
+
+	return b.backup(backup.Name, now), nil
+}
+
+// List returns the backups of the task store, newest first
+func (b *Backups) List() ([]Backup, error) {
+	entries, err := os.ReadDir(b.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	stem := b.stem()
+	var backups []Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, stem+"-") {
+			continue
+		}
+
+		taken, err := time.ParseInLocation(backupTimeLayout, strings.TrimPrefix(name, stem+"-"), time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, b.backup(name, taken))
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Time.After(backups[j].Time) })
+	return backups, nil
+}
+
+// Restore replaces the task store and its related files with the named
+// backup. A related file missing from the backup is removed. The current
+// store is backed up first, so a restore can be undone.
+func (b *Backups) Restore(name string) (Backup, error) {
+	backups, err := b.List()
+	if err != nil {
+		return Backup{}, err
+	}
+
+	var restored *Backup
+	for i := range backups {
+		if backups[i].Name == name {
+			restored = &backups[i]
+			break
+		}
+	}
+	if restored == nil {
+		return Backup{}, fmt.Errorf("backup not found: %s", name)
+	}
+
+	// Copy the backup next to the store first so a failed copy leaves the
+	// store intact and rotating the backups below cannot remove it
+	tmp := restoreName(b.storePath)
+	tmpRelated := make([]string, len(b.related))
+	for i, path := range b.related {
+		tmpRelated[i] = restoreName(path)
+	}
+	removeTmp := func() {
+		for _, path := range append([]string{tmp}, tmpRelated...) {
+			os.RemoveAll(path)
+		}
+	}
+
+	removeTmp()
+	if err := copyFiles(tmp, restored.Path, tmpRelated, b.backupRelated(*restored)); err != nil {
+		removeTmp()
+		return Backup{}, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	var current Backup
+	if _, err := os.Stat(b.storePath); err == nil {
+		if current, err = b.Create(); err != nil {
+			removeTmp()
+			return Backup{}, err
+		}
+	}
+
+	for i, path := range append([]string{b.storePath}, b.related...) {
+		from := tmp
+		if i > 0 {
+			from = tmpRelated[i-1]
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return Backup{}, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		if _, err := os.Stat(from); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(from, path); err != nil {
+			return Backup{}, fmt.Errorf("failed to restore backup: %w", err)
+		}
+	}
+
+	return current, nil
+}
+
+// rotate removes the backups beyond the policy's count and age
+func (b *Backups) rotate(now time.Time) error {
+	backups, err := b.List()
+	if err != nil {
+		return err
+	}
+
+	for i, backup := range backups {
+		tooMany := b.policy.Count > 0 && i >= b.policy.Count
+		tooOld := b.policy.MaxAge > 0 && now.Sub(backup.Time) > b.policy.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(b.dir, backup.Name)); err != nil {
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backup describes the named backup, listing the copies of the related
+// files it holds
+func (b *Backups) backup(name string, taken time.Time) Backup {
+	backup := Backup{
+		Name: name,
+		Path: filepath.Join(b.dir, name, filepath.Base(b.storePath)),
+		Time: taken,
+	}
+	for _, path := range b.backupRelated(backup) {
+		if _, err := os.Stat(path); err == nil {
+			backup.Related = append(backup.Related, path)
+		}
+	}
+	return backup
+}
+
+// backupRelated returns where a backup keeps the copy of each related file,
+// whether or not the copy exists
+func (b *Backups) backupRelated(backup Backup) []string {
+	paths := make([]string, len(b.related))
+	for i, path := range b.related {
+		paths[i] = filepath.Join(filepath.Dir(backup.Path), filepath.Base(path))
+	}
+	return paths
+}
+
+// copyFiles copies the store at src to dst and each existing related file
+// in srcRelated to the matching path in dstRelated
+func copyFiles(dst, src string, dstRelated, srcRelated []string) error {
+	if err := copyPath(dst, src); err != nil {
+		return err
+	}
+	for i, path := range srcRelated {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := copyFile(dstRelated[i], path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stem returns the store's file name without its extension, which starts
+// the name of its backups, e.g. "tasks"
+func (b *Backups) stem() string {
+	base := filepath.Base(b.storePath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// restoreName returns the temporary path a file is restored to before it
+// replaces the file at path
+func restoreName(path string) string {
+	return path + ".restore"
+}
+
+// copyPath copies the file or directory tree at src to dst
+func copyPath(dst, src string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(target, path)
+	})
+}
+
+// copyFile copies a single file, syncing it to disk before returning
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// BackupStorage wraps a Storage, backing the task store up before its first
+// write, so that a command writing many times takes a single backup of the
+// store as it was before the command
+type BackupStorage struct {
+	Storage
+	backups *Backups
+	once    sync.Once
+	err     error
+}
+
+// NewBackupStorage creates a BackupStorage around store
+func NewBackupStorage(store Storage, backups *Backups) *BackupStorage {
+	return &BackupStorage{Storage: store, backups: backups}
+}
+
+// AddTask backs the store up if needed and adds a task
+func (s *BackupStorage) AddTask(task models.Task) (models.Task, error) {
+	if err := s.backup(); err != nil {
+		return models.Task{}, err
+	}
+	return s.Storage.AddTask(task)
+}
+
+// UpdateTask backs the store up if needed and updates a task
+func (s *BackupStorage) UpdateTask(task models.Task) error {
+	if err := s.backup(); err != nil {
+		return err
+	}
+	return s.Storage.UpdateTask(task)
+}
+
+// DeleteTask backs the store up if needed and removes a task
+func (s *BackupStorage) DeleteTask(id int) error {
+	if err := s.backup(); err != nil {
+		return err
+	}
+	return s.Storage.DeleteTask(id)
+}
+
+// ReplaceTasks backs the store up if needed and replaces every task
+func (s *BackupStorage) ReplaceTasks(tasks []models.Task) error {
+	replacer, ok := s.Storage.(Replacer)
+	if !ok {
+		return errors.New("storage cannot replace its tasks")
+	}
+	if err := s.backup(); err != nil {
+		return err
+	}
+	return replacer.ReplaceTasks(tasks)
+}
+
+// backup takes the automatic backup on the first write unless the policy
+// disables them
+func (s *BackupStorage) backup() error {
+	s.once.Do(func() {
+		if s.backups.policy.Count == 0 {
+			return
+		}
+		if _, err := s.backups.Create(); err != nil {
+			s.err = fmt.Errorf("failed to back up task store: %w", err)
+		}
+	})
+	return s.err
+}
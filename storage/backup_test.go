@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestBackups(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "backup-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	jsonStorage, err := NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	milestonesPath := filepath.Join(tempDir, "tasks.milestones.json")
+	backups := NewBackups(filePath, filepath.Join(tempDir, "backups"), BackupPolicy{Count: 2}, milestonesPath)
+
+	// Test backing up before the first write of each command
+	var storage *BackupStorage
+	for _, title := range []string{"First", "Second", "Third"} {
+		storage = NewBackupStorage(jsonStorage, backups)
+		if _, err := storage.AddTask(models.Task{Title: title, Status: models.StatusTodo}); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	list, err := backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 backups to be kept, got %d", len(list))
+	}
+
+	if !list[0].Time.After(list[1].Time) {
+		t.Error("Expected backups to be listed newest first")
+	}
+
+	// Test restoring the newest backup, taken before the third task was added
+	replaced, err := backups.Restore(list[0].Name)
+	if err != nil {
+		t.Fatalf("Failed to restore backup: %v", err)
+	}
+
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Errorf("Expected 2 tasks after restore, got %d", len(tasks))
+	}
+
+	// Test undoing the restore with the backup of the replaced store
+	if _, err := backups.Restore(replaced.Name); err != nil {
+		t.Fatalf("Failed to restore replaced store: %v", err)
+	}
+
+	tasks, err = storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != 3 {
+		t.Errorf("Expected 3 tasks after undoing restore, got %d", len(tasks))
+	}
+
+	if _, err := backups.Restore("tasks-unknown"); err == nil {
+		t.Error("Expected error when restoring unknown backup, got nil")
+	}
+
+	// Test a command writing many times keeping the state before it
+	storage = NewBackupStorage(jsonStorage, backups)
+	for i := 0; i < 3; i++ {
+		if _, err := storage.AddTask(models.Task{Title: "Imported", Status: models.StatusTodo}); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	list, err = backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+
+	backup, err := NewJSONStorage(list[0].Path)
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+
+	if tasks, _ := backup.GetTasks(); len(tasks) != 3 {
+		t.Errorf("Expected the newest backup to hold the 3 tasks before the command, got %d", len(tasks))
+	}
+}
+
+func TestBackupsMaxAge(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "backup-age-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	if _, err := NewJSONStorage(filePath); err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	backups := NewBackups(filePath, filepath.Join(tempDir, "backups"), BackupPolicy{MaxAge: 24 * time.Hour})
+
+	old := "tasks-" + time.Now().Add(-48*time.Hour).Format(backupTimeLayout)
+	if err := os.MkdirAll(filepath.Join(backups.Dir(), old), 0755); err != nil {
+		t.Fatalf("Failed to create backup directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(backups.Dir(), old, "tasks.json"), []byte("[]"), 0644); err != nil {
+		t.Fatalf("Failed to write old backup: %v", err)
+	}
+
+	if _, err := backups.Create(); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	list, err := backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+
+	if len(list) != 1 || list[0].Name == old {
+		t.Errorf("Expected only the new backup to be kept, got %v", list)
+	}
+}
+
+func TestBackupsDirectory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "backup-dir-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dir := filepath.Join(tempDir, "tasks")
+	markdownStorage, err := NewMarkdownStorage(dir)
+	if err != nil {
+		t.Fatalf("Failed to create markdown storage: %v", err)
+	}
+
+	backups := NewBackups(dir, filepath.Join(tempDir, "backups"), BackupPolicy{Count: 5})
+
+	NewBackupStorage(markdownStorage, backups).AddTask(models.Task{Title: "Keep", Status: models.StatusTodo})
+	storage := NewBackupStorage(markdownStorage, backups)
+	storage.AddTask(models.Task{Title: "Extra", Status: models.StatusTodo})
+
+	list, err := backups.List()
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+
+	// Restore the state before the second task was added
+	if _, err := backups.Restore(list[0].Name); err != nil {
+		t.Fatalf("Failed to restore backup: %v", err)
+	}
+
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].Title != "Keep" {
+		t.Errorf("Expected only task 'Keep' after restore, got %v", tasks)
+	}
+}
+
+func TestBackupsRelatedFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "backup-related-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.json")
+	if _, err := NewJSONStorage(filePath); err != nil {
+		t.Fatalf("Failed to create JSON storage: %v", err)
+	}
+
+	milestonesPath := filepath.Join(tempDir, "tasks.milestones.json")
+	milestones, err := NewJSONMilestoneStorage(milestonesPath)
+	if err != nil {
+		t.Fatalf("Failed to create milestone storage: %v", err)
+	}
+
+	backups := NewBackups(filePath, filepath.Join(tempDir, "backups"), BackupPolicy{Count: 5}, milestonesPath)
+
+	// A backup taken before the milestones file exists does not hold one
+	empty, err := backups.Create()
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	if len(empty.Related) != 0 {
+		t.Errorf("Expected no related files in the backup, got %v", empty.Related)
+	}
+
+	if err := milestones.SaveMilestone(models.Milestone{Name: "v1.0"}); err != nil {
+		t.Fatalf("Failed to add milestone: %v", err)
+	}
+
+	withMilestone, err := backups.Create()
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	if len(withMilestone.Related) != 1 {
+		t.Fatalf("Expected the milestones in the backup, got %v", withMilestone.Related)
+	}
+
+	if err := milestones.SaveMilestone(models.Milestone{Name: "v2.0"}); err != nil {
+		t.Fatalf("Failed to add milestone: %v", err)
+	}
+
+	// Test restoring the milestones along with the store
+	if _, err := backups.Restore(withMilestone.Name); err != nil {
+		t.Fatalf("Failed to restore backup: %v", err)
+	}
+
+	list, err := milestones.GetMilestones()
+	if err != nil {
+		t.Fatalf("Failed to get milestones: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "v1.0" {
+		t.Errorf("Expected only milestone v1.0 after restore, got %v", list)
+	}
+
+	// Test restoring a backup without milestones removing them
+	if _, err := backups.Restore(empty.Name); err != nil {
+		t.Fatalf("Failed to restore backup: %v", err)
+	}
+
+	if _, err := os.Stat(milestonesPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the milestones file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedMagic starts every encrypted task store file
+var encryptedMagic = []byte("CTMENC1")
+
+// Key derivation modes recorded in the header of encrypted files
+const (
+	modeKey        byte = 0
+	modePassphrase byte = 1
+)
+
+const (
+	// KeySize is the size in bytes of an encryption key
+	KeySize   = 32
+	saltSize  = 16
+	scryptN   = 1 << 15
+	scryptR   = 8
+	scryptP   = 1
+	nonceSize = 12
+)
+
+// Cipher encrypts and authenticates task store files with AES-256-GCM,
+// using either a key or a key derived from a passphrase with scrypt
+type Cipher struct {
+	passphrase []byte
+	key        []byte
+	salt       []byte
+	mutex      sync.Mutex
+}
+
+// NewKeyCipher creates a Cipher using a KeySize bytes key
+func NewKeyCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return &Cipher{key: key}, nil
+}
+
+// NewPassphraseCipher creates a Cipher deriving its key from a passphrase
+func NewPassphraseCipher(passphrase string) (*Cipher, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase cannot be empty")
+	}
+	return &Cipher{passphrase: []byte(passphrase)}, nil
+}
+
+// GenerateKey returns a new random key encoded as hex
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// ParseKey decodes a key given as hex or base64
+func ParseKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if key, err := hex.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key must be %d bytes encoded as hex or base64", KeySize)
+}
+
+// IsEncrypted reports whether data is an encrypted task store file
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// IsEncryptedFile reports whether the file at path is encrypted. It returns
+// false for directories and files that do not exist.
+func IsEncryptedFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.IsDir() {
+		return false, nil
+	}
+
+	header := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false, nil
+	}
+	return IsEncrypted(header), nil
+}
+
+// IsPassphraseEncryptedFile reports whether the file at path is encrypted
+// with a passphrase rather than a key
+func IsPassphraseEncryptedFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	header := make([]byte, len(encryptedMagic)+1)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false, nil
+	}
+	return IsEncrypted(header) && header[len(encryptedMagic)] == modePassphrase, nil
+}
+
+// Encrypt encrypts plaintext into the encrypted file format: a header of the
+// magic, the key derivation mode and the salt, followed by the nonce and the
+// sealed data. The header is authenticated along with the data.
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	header := append([]byte{}, encryptedMagic...)
+	if c.passphrase == nil {
+		header = append(header, modeKey)
+	} else {
+		if c.salt == nil {
+			salt := make([]byte, saltSize)
+			if _, err := rand.Read(salt); err != nil {
+				return nil, fmt.Errorf("failed to generate salt: %w", err)
+			}
+			if err := c.derive(salt); err != nil {
+				return nil, err
+			}
+		}
+		header = append(append(header, modePassphrase), c.salt...)
+	}
+
+	aead, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data := append(header, nonce...)
+	return aead.Seal(data, nonce, plaintext, header), nil
+}
+
+// Decrypt decrypts and authenticates data written by Encrypt
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !IsEncrypted(data) {
+		return nil, errors.New("file is not encrypted")
+	}
+	if len(data) <= len(encryptedMagic) {
+		return nil, errors.New("encrypted file is truncated")
+	}
+
+	headerSize := len(encryptedMagic) + 1
+	switch data[len(encryptedMagic)] {
+	case modeKey:
+		if c.passphrase != nil {
+			return nil, errors.New("file is encrypted with a key, not a passphrase")
+		}
+	case modePassphrase:
+		if c.passphrase == nil {
+			return nil, errors.New("file is encrypted with a passphrase, not a key")
+		}
+		headerSize += saltSize
+		if len(data) < headerSize {
+			return nil, errors.New("encrypted file is truncated")
+		}
+		if salt := data[headerSize-saltSize : headerSize]; !bytes.Equal(salt, c.salt) {
+			if err := c.derive(salt); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.New("unknown encryption mode")
+	}
+
+	if len(data) < headerSize+nonceSize {
+		return nil, errors.New("encrypted file is truncated")
+	}
+
+	aead, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	header := data[:headerSize]
+	nonce := data[headerSize : headerSize+nonceSize]
+	plaintext, err := aead.Open(nil, nonce, data[headerSize+nonceSize:], header)
+	if err != nil {
+		return nil, errors.New("failed to decrypt: wrong key or passphrase, or the file was modified")
+	}
+
+	return plaintext, nil
+}
+
+// derive derives the key from the passphrase and salt
+func (c *Cipher) derive(salt []byte) error {
+	key, err := scrypt.Key(c.passphrase, salt, scryptN, scryptR, scryptP, KeySize)
+	if err != nil {
+		return fmt.Errorf("failed to derive key: %w", err)
+	}
+	c.key = key
+	c.salt = append([]byte{}, salt...)
+	return nil
+}
+
+// aead returns the AES-GCM cipher of the current key
+func (c *Cipher) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(c.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// readFile reads a task store file, decrypting it when c is set
+func readFile(path string, c *Cipher) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil || c == nil {
+		return data, err
+	}
+	return c.Decrypt(data)
+}
+
+// writeFile writes a task store file, encrypting it when c is set
+func writeFile(path string, data []byte, c *Cipher) error {
+	if c == nil {
+		return os.WriteFile(path, data, 0644)
+	}
+
+	encrypted, err := c.Encrypt(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, encrypted, 0600)
+}
+
+// EncryptFile encrypts the task store file at path in place
+func EncryptFile(path string, c *Cipher) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if IsEncrypted(data) {
+		return errors.New("the task store is already encrypted")
+	}
+
+	encrypted, err := c.Encrypt(data)
+	if err != nil {
+		return err
+	}
+	return replaceFile(path, encrypted, 0600)
+}
+
+// DecryptFile decrypts the task store file at path in place
+func DecryptFile(path string, c *Cipher) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if !IsEncrypted(data) {
+		return errors.New("the task store is not encrypted")
+	}
+
+	plaintext, err := c.Decrypt(data)
+	if err != nil {
+		return err
+	}
+	return replaceFile(path, plaintext, 0644)
+}
+
+// replaceFile replaces the file at path through a temporary file, so that
+// the file is never left half written
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
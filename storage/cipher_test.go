@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestCipher(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	rawKey, err := ParseKey(key)
+	if err != nil {
+		t.Fatalf("Failed to parse generated key: %v", err)
+	}
+
+	keyCipher, err := NewKeyCipher(rawKey)
+	if err != nil {
+		t.Fatalf("Failed to create key cipher: %v", err)
+	}
+
+	passphraseCipher, err := NewPassphraseCipher("correct horse")
+	if err != nil {
+		t.Fatalf("Failed to create passphrase cipher: %v", err)
+	}
+
+	plaintext := []byte(`[{"id": 1, "title": "Call ACME"}]`)
+
+	// Test round trips with both kinds of keys
+	for name, c := range map[string]*Cipher{"key": keyCipher, "passphrase": passphraseCipher} {
+		encrypted, err := c.Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("Failed to encrypt with %s: %v", name, err)
+		}
+
+		if !IsEncrypted(encrypted) || bytes.Contains(encrypted, []byte("ACME")) {
+			t.Errorf("Expected %s encrypted data to hide the plaintext", name)
+		}
+
+		decrypted, err := c.Decrypt(encrypted)
+		if err != nil {
+			t.Fatalf("Failed to decrypt with %s: %v", name, err)
+		}
+
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Errorf("Expected %s round trip to return %s, got %s", name, plaintext, decrypted)
+		}
+
+		// Test detecting modified data
+		encrypted[len(encrypted)-1] ^= 1
+		if _, err := c.Decrypt(encrypted); err == nil {
+			t.Errorf("Expected error when decrypting modified %s data, got nil", name)
+		}
+	}
+
+	// Test rejecting the wrong passphrase and the wrong kind of key
+	encrypted, err := passphraseCipher.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	wrong, _ := NewPassphraseCipher("wrong horse")
+	if _, err := wrong.Decrypt(encrypted); err == nil {
+		t.Error("Expected error when decrypting with the wrong passphrase, got nil")
+	}
+
+	if _, err := keyCipher.Decrypt(encrypted); err == nil {
+		t.Error("Expected error when decrypting passphrase data with a key, got nil")
+	}
+
+	if _, err := keyCipher.Decrypt(plaintext); err == nil {
+		t.Error("Expected error when decrypting plaintext, got nil")
+	}
+
+	// Test key validation
+	if _, err := ParseKey("not a key"); err == nil {
+		t.Error("Expected error when parsing invalid key, got nil")
+	}
+
+	if _, err := NewKeyCipher([]byte("short")); err == nil {
+		t.Error("Expected error for short key, got nil")
+	}
+
+	if _, err := NewPassphraseCipher(""); err == nil {
+		t.Error("Expected error for empty passphrase, got nil")
+	}
+}
+
+func TestOpenEncrypted(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "encrypted-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	c, err := NewPassphraseCipher("correct horse")
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+
+	// Test every file backend keeps its file encrypted
+	for _, backend := range []string{BackendJSON, BackendYAML, BackendTodoTxt} {
+		path := filepath.Join(tempDir, FileName(backend))
+		store, err := OpenEncrypted(backend, path, models.DefaultWorkflow(), c)
+		if err != nil {
+			t.Fatalf("Failed to open encrypted %s storage: %v", backend, err)
+		}
+
+		if _, err := store.AddTask(models.Task{Title: "Call ACME", Status: models.StatusTodo, Label: "task"}); err != nil {
+			t.Fatalf("Failed to add task to encrypted %s storage: %v", backend, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s file: %v", backend, err)
+		}
+
+		if !IsEncrypted(data) || bytes.Contains(data, []byte("ACME")) {
+			t.Errorf("Expected %s file to be encrypted", backend)
+		}
+
+		task, err := store.GetTaskByID(1)
+		if err != nil {
+			t.Fatalf("Failed to get task from encrypted %s storage: %v", backend, err)
+		}
+
+		if task.Title != "Call ACME" {
+			t.Errorf("Expected title 'Call ACME' in %s storage, got %s", backend, task.Title)
+		}
+
+		// Test converting the file back and forth
+		if err := DecryptFile(path, c); err != nil {
+			t.Fatalf("Failed to decrypt %s file: %v", backend, err)
+		}
+
+		if encrypted, _ := IsEncryptedFile(path); encrypted {
+			t.Errorf("Expected %s file to be decrypted", backend)
+		}
+
+		if err := EncryptFile(path, c); err != nil {
+			t.Fatalf("Failed to encrypt %s file: %v", backend, err)
+		}
+
+		if err := EncryptFile(path, c); err == nil {
+			t.Errorf("Expected error when encrypting encrypted %s file, got nil", backend)
+		}
+	}
+
+	if _, err := OpenEncrypted(BackendMarkdown, filepath.Join(tempDir, "tasks"), models.DefaultWorkflow(), c); err == nil {
+		t.Error("Expected error when encrypting markdown storage, got nil")
+	}
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// JSONMilestoneStorage implements the MilestoneStorage interface using a JSON file
+type JSONMilestoneStorage struct {
+	filePath string
+	cipher   *Cipher
+	mutex    sync.RWMutex
+}
+
+// NewJSONMilestoneStorage creates a new JSONMilestoneStorage instance
+func NewJSONMilestoneStorage(filePath string) (*JSONMilestoneStorage, error) {
+	return NewEncryptedJSONMilestoneStorage(filePath, nil)
+}
+
+// NewEncryptedJSONMilestoneStorage creates a JSONMilestoneStorage keeping its
+// file encrypted with c. A milestones file left unencrypted is encrypted
+// right away. A nil cipher keeps the file unencrypted.
+func NewEncryptedJSONMilestoneStorage(filePath string, c *Cipher) (*JSONMilestoneStorage, error) {
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if c != nil {
+		encrypted, err := IsEncryptedFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if _, err := os.Stat(filePath); err == nil && !encrypted {
+			if err := EncryptFile(filePath, c); err != nil {
+				return nil, fmt.Errorf("failed to encrypt milestones: %w", err)
+			}
+		}
+	}
+
+	return &JSONMilestoneStorage{
+		filePath: filePath,
+		cipher:   c,
+	}, nil
+}
+
+// GetMilestones returns all milestones from the JSON file
+func (s *JSONMilestoneStorage) GetMilestones() ([]models.Milestone, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.readMilestones()
+}
+
+// GetMilestone retrieves a milestone by its name
+func (s *JSONMilestoneStorage) GetMilestone(name string) (models.Milestone, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	milestones, err := s.readMilestones()
+	if err != nil {
+		return models.Milestone{}, err
+	}
+
+	for _, m := range milestones {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+
+	return models.Milestone{}, errors.New("milestone not found")
+}
+
+// SaveMilestone adds or replaces a milestone in the JSON file
+func (s *JSONMilestoneStorage) SaveMilestone(milestone models.Milestone) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	milestones, err := s.readMilestones()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, m := range milestones {
+		if m.Name == milestone.Name {
+			milestones[i] = milestone
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		milestones = append(milestones, milestone)
+	}
+
+	return s.writeMilestones(milestones)
+}
+
+// DeleteMilestone removes a milestone by name from the JSON file
+func (s *JSONMilestoneStorage) DeleteMilestone(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	milestones, err := s.readMilestones()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	var updatedMilestones []models.Milestone
+	for _, m := range milestones {
+		if m.Name != name {
+			updatedMilestones = append(updatedMilestones, m)
+		} else {
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New("milestone not found")
+	}
+
+	return s.writeMilestones(updatedMilestones)
+}
+
+// readMilestones reads all milestones from the JSON file, which is created on first write
+func (s *JSONMilestoneStorage) readMilestones() ([]models.Milestone, error) {
+	data, err := readFile(s.filePath, s.cipher)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var milestones []models.Milestone
+	if err := json.Unmarshal(data, &milestones); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal milestones: %w", err)
+	}
+
+	return milestones, nil
+}
+
+// writeMilestones writes all milestones to the JSON file
+func (s *JSONMilestoneStorage) writeMilestones(milestones []models.Milestone) error {
+	if milestones == nil {
+		milestones = []models.Milestone{}
+	}
+
+	data, err := json.MarshalIndent(milestones, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal milestones: %w", err)
+	}
+
+	if err := writeFile(s.filePath, data, s.cipher); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestJSONMilestoneStorage(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "json-milestone-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir) // Clean up after test
+
+	storage, err := NewJSONMilestoneStorage(filepath.Join(tempDir, "milestones.json"))
+	if err != nil {
+		t.Fatalf("Failed to create JSON milestone storage: %v", err)
+	}
+
+	// Test reading before the file exists
+	milestones, err := storage.GetMilestones()
+	if err != nil {
+		t.Fatalf("Failed to get milestones: %v", err)
+	}
+
+	if len(milestones) != 0 {
+		t.Errorf("Expected no milestones, got %d", len(milestones))
+	}
+
+	// Test saving a milestone
+	if err := storage.SaveMilestone(models.Milestone{Name: "sprint-1"}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	// Test replacing a milestone
+	if err := storage.SaveMilestone(models.Milestone{Name: "sprint-1", Description: "First sprint"}); err != nil {
+		t.Fatalf("Failed to replace milestone: %v", err)
+	}
+
+	milestone, err := storage.GetMilestone("sprint-1")
+	if err != nil {
+		t.Fatalf("Failed to get milestone: %v", err)
+	}
+
+	if milestone.Description != "First sprint" {
+		t.Errorf("Expected description to be 'First sprint', got %s", milestone.Description)
+	}
+
+	milestones, _ = storage.GetMilestones()
+	if len(milestones) != 1 {
+		t.Errorf("Expected 1 milestone, got %d", len(milestones))
+	}
+
+	// Test deleting a milestone
+	if err := storage.DeleteMilestone("sprint-1"); err != nil {
+		t.Fatalf("Failed to delete milestone: %v", err)
+	}
+
+	if _, err := storage.GetMilestone("sprint-1"); err == nil {
+		t.Error("Expected error when getting deleted milestone, got nil")
+	}
+
+	if err := storage.DeleteMilestone("sprint-1"); err == nil {
+		t.Error("Expected error when deleting non-existent milestone, got nil")
+	}
+}
+
+func TestEncryptedJSONMilestoneStorage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "encrypted-milestone-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, "tasks.milestones.json")
+	plain, err := NewJSONMilestoneStorage(filePath)
+	if err != nil {
+		t.Fatalf("Failed to create milestone storage: %v", err)
+	}
+	if err := plain.SaveMilestone(models.Milestone{Name: "ACME rollout"}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	c, err := NewPassphraseCipher("correct horse")
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+
+	// Test an unencrypted file being encrypted when opened with a cipher
+	storage, err := NewEncryptedJSONMilestoneStorage(filePath, c)
+	if err != nil {
+		t.Fatalf("Failed to create encrypted milestone storage: %v", err)
+	}
+
+	if encrypted, _ := IsEncryptedFile(filePath); !encrypted {
+		t.Error("Expected the milestones file to be encrypted")
+	}
+
+	if _, err := storage.GetMilestone("ACME rollout"); err != nil {
+		t.Errorf("Expected milestone to be readable, got %v", err)
+	}
+
+	if _, err := plain.GetMilestones(); err == nil {
+		t.Error("Expected error when reading encrypted milestones without a cipher, got nil")
+	}
+}
@@ -14,11 +14,17 @@ import (
 // JSONStorage implements the Storage interface using a JSON file
 type JSONStorage struct {
 	filePath string
+	cipher   *Cipher
 	mutex    sync.RWMutex
 }
 
 // NewJSONStorage creates a new JSONStorage instance
 func NewJSONStorage(filePath string) (*JSONStorage, error) {
+	return newJSONStorage(filePath, nil)
+}
+
+// newJSONStorage creates a JSONStorage encrypting its file with c, if set
+func newJSONStorage(filePath string, c *Cipher) (*JSONStorage, error) {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -27,13 +33,14 @@ func NewJSONStorage(filePath string) (*JSONStorage, error) {
 
 	// Create file if it doesn't exist
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		if err := os.WriteFile(filePath, []byte("[]"), 0644); err != nil {
+		if err := writeFile(filePath, []byte("[]"), c); err != nil {
 			return nil, fmt.Errorf("failed to create file: %w", err)
 		}
 	}
 
 	return &JSONStorage{
 		filePath: filePath,
+		cipher:   c,
 	}, nil
 }
 
@@ -42,7 +49,7 @@ func (s *JSONStorage) GetTasks() ([]models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	data, err := os.ReadFile(s.filePath)
+	data, err := readFile(s.filePath, s.cipher)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -155,9 +162,17 @@ func (s *JSONStorage) GetTaskByID(id int) (models.Task, error) {
 	return models.Task{}, errors.New("task not found")
 }
 
+// ReplaceTasks replaces every task in the JSON file
+func (s *JSONStorage) ReplaceTasks(tasks []models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.writeTasks(tasks)
+}
+
 // readTasks reads all tasks from the JSON file
 func (s *JSONStorage) readTasks() ([]models.Task, error) {
-	data, err := os.ReadFile(s.filePath)
+	data, err := readFile(s.filePath, s.cipher)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -177,7 +192,7 @@ func (s *JSONStorage) writeTasks(tasks []models.Task) error {
 		return fmt.Errorf("failed to marshal tasks: %w", err)
 	}
 
-	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+	if err := writeFile(s.filePath, data, s.cipher); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
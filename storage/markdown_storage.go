@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// markdownFileName matches task files named <id>-<slug>.md
+var markdownFileName = regexp.MustCompile(`^(\d+)(-[^/]*)?\.md$`)
+
+// frontMatterDelimiter opens and closes the YAML front matter of a task file
+const frontMatterDelimiter = "---"
+
+// MarkdownStorage implements the Storage interface using a directory holding
+// one Markdown file per task. The metadata of a task is kept in YAML front
+// matter, followed by the title as heading and the description.
+type MarkdownStorage struct {
+	dir   string
+	mutex sync.RWMutex
+}
+
+// NewMarkdownStorage creates a new MarkdownStorage instance
+func NewMarkdownStorage(dir string) (*MarkdownStorage, error) {
+	// Create directory if it doesn't exist
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return &MarkdownStorage{
+		dir: dir,
+	}, nil
+}
+
+// GetTasks returns all tasks from the task files, ordered by ID
+func (s *MarkdownStorage) GetTasks() ([]models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(files))
+	for id := range files {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	tasks := make([]models.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := s.readTask(id, files[id])
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// AddTask writes a new task file
+func (s *MarkdownStorage) AddTask(task models.Task) (models.Task, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	// Generate a new ID
+	maxID := 0
+	for id := range files {
+		if id > maxID {
+			maxID = id
+		}
+	}
+	task.ID = maxID + 1
+
+	if err := s.writeTask(task, ""); err != nil {
+		return models.Task{}, err
+	}
+
+	return task, nil
+}
+
+// UpdateTask rewrites the file of an existing task, renaming it when the
+// title changed
+func (s *MarkdownStorage) UpdateTask(task models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return err
+	}
+
+	path, ok := files[task.ID]
+	if !ok {
+		return errors.New("task not found")
+	}
+
+	return s.writeTask(task, path)
+}
+
+// DeleteTask removes the file of a task
+func (s *MarkdownStorage) DeleteTask(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return err
+	}
+
+	path, ok := files[id]
+	if !ok {
+		return errors.New("task not found")
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaskByID retrieves a task by its ID
+func (s *MarkdownStorage) GetTaskByID(id int) (models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	path, ok := files[id]
+	if !ok {
+		return models.Task{}, errors.New("task not found")
+	}
+
+	return s.readTask(id, path)
+}
+
+// ReplaceTasks removes every task file and writes one file per given task
+func (s *MarkdownStorage) ReplaceTasks(tasks []models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Check every title first so that a task the backend cannot hold does
+	// not leave the store half replaced
+	for _, task := range tasks {
+		if err := checkMarkdownTitle(task.Title); err != nil {
+			return fmt.Errorf("task %d: %w", task.ID, err)
+		}
+	}
+
+	files, err := s.taskFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove file: %w", err)
+		}
+	}
+
+	for _, task := range tasks {
+		if err := s.writeTask(task, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// taskFiles returns the paths of the task files by ID
+func (s *MarkdownStorage) taskFiles() (map[int]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	files := make(map[int]string)
+	for _, entry := range entries {
+		match := markdownFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		id, err := strconv.Atoi(match[1])
+		if err != nil || id == 0 {
+			continue
+		}
+		if other, ok := files[id]; ok {
+			return nil, fmt.Errorf("duplicate task ID %d: %s and %s", id, filepath.Base(other), entry.Name())
+		}
+		files[id] = filepath.Join(s.dir, entry.Name())
+	}
+
+	return files, nil
+}
+
+// readTask parses a task file
+func (s *MarkdownStorage) readTask(id int, path string) (models.Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.Task{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	task, err := parseMarkdownTask(data)
+	if err != nil {
+		return models.Task{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	task.ID = id
+
+	return task, nil
+}
+
+// writeTask writes the file of a task, removing its previous file if the
+// name changed
+func (s *MarkdownStorage) writeTask(task models.Task, previous string) error {
+	data, err := formatMarkdownTask(task)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, markdownTaskFileName(task))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if previous != "" && previous != path {
+		if err := os.Remove(previous); err != nil {
+			return fmt.Errorf("failed to remove renamed file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// markdownTaskFileName returns <id>-<slug>.md for a task
+func markdownTaskFileName(task models.Task) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(task.Title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug.WriteRune(r)
+			dash = false
+		case !dash && slug.Len() > 0:
+			slug.WriteRune('-')
+			dash = true
+		}
+		if slug.Len() >= 50 {
+			break
+		}
+	}
+
+	name := strings.TrimSuffix(slug.String(), "-")
+	if name == "" {
+		return fmt.Sprintf("%d.md", task.ID)
+	}
+	return fmt.Sprintf("%d-%s.md", task.ID, name)
+}
+
+// checkMarkdownTitle rejects titles that cannot be kept in a single heading
+// line, which would leave the task file unreadable
+func checkMarkdownTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return errors.New("the markdown backend requires a task title")
+	}
+	if strings.ContainsAny(title, "\r\n") {
+		return errors.New("the markdown backend cannot store a title spanning several lines")
+	}
+	return nil
+}
+
+// formatMarkdownTask renders a task as front matter, heading and description.
+// The ID is kept in the file name only.
+func formatMarkdownTask(task models.Task) ([]byte, error) {
+	if err := checkMarkdownTitle(task.Title); err != nil {
+		return nil, err
+	}
+
+	meta := task
+	meta.ID, meta.Title, meta.Description = 0, "", ""
+
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(meta); err != nil {
+		return nil, fmt.Errorf("failed to marshal front matter: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal front matter: %w", err)
+	}
+
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString("# " + task.Title + "\n")
+	if task.Description != "" {
+		buf.WriteString("\n" + strings.TrimRight(task.Description, "\n") + "\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseMarkdownTask parses a task file rendered by formatMarkdownTask
+func parseMarkdownTask(data []byte) (models.Task, error) {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	rest, ok := strings.CutPrefix(content, frontMatterDelimiter+"\n")
+	if !ok {
+		return models.Task{}, errors.New("missing front matter")
+	}
+	frontMatter, body, ok := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+	if !ok {
+		return models.Task{}, errors.New("unterminated front matter")
+	}
+
+	var task models.Task
+	if err := yaml.Unmarshal([]byte(frontMatter), &task); err != nil {
+		return models.Task{}, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	body = strings.TrimLeft(body, "\n")
+	heading, description, _ := strings.Cut(body, "\n")
+	title, ok := strings.CutPrefix(heading, "# ")
+	if !ok || strings.TrimSpace(title) == "" {
+		return models.Task{}, errors.New("missing title heading")
+	}
+
+	task.Title = strings.TrimSpace(title)
+	task.Description = strings.TrimSpace(description)
+
+	return task, nil
+}
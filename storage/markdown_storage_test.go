@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestMarkdownStorage(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "markdown-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir) // Clean up after test
+
+	dir := filepath.Join(tempDir, "tasks")
+	storage, err := NewMarkdownStorage(dir)
+	if err != nil {
+		t.Fatalf("Failed to create Markdown storage: %v", err)
+	}
+
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	estimate := models.Estimate{Duration: 2 * time.Hour}
+	task := models.Task{
+		Title:       "Fix login: redirect loop!",
+		Description: "Steps to reproduce\n\n1. Log in",
+		Label:       "bug",
+		Status:      models.StatusTodo,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		Estimate:    &estimate,
+	}
+	task.SetField("customer", "Acme")
+	task.SetStatus(models.StatusInProgress, created.Add(time.Hour))
+
+	addedTask, err := storage.AddTask(task)
+	if err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	if addedTask.ID != 1 {
+		t.Errorf("Expected task ID to be 1, got %d", addedTask.ID)
+	}
+
+	path := filepath.Join(dir, "1-fix-login-redirect-loop.md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected task file %s: %v", path, err)
+	}
+	if !strings.HasPrefix(string(data), "---\nstatus: in-progress\nlabel: bug\n") || !strings.Contains(string(data), "\n---\n\n# Fix login: redirect loop!\n\nSteps to reproduce") {
+		t.Errorf("Unexpected task file:\n%s", data)
+	}
+
+	got, err := storage.GetTaskByID(1)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if got.Title != task.Title || got.Description != task.Description || got.Field("customer") != "Acme" {
+		t.Errorf("Expected task to round trip, got %+v", got)
+	}
+	if got.Estimate == nil || *got.Estimate != estimate || len(got.History) != 1 || !got.CreatedAt.Equal(created) {
+		t.Errorf("Expected metadata to round trip, got %+v", got)
+	}
+
+	// Test renaming the file when the title changes
+	got.Title = "Fix logout"
+	if err := storage.UpdateTask(got); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the old task file to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1-fix-logout.md")); err != nil {
+		t.Errorf("Expected the renamed task file: %v", err)
+	}
+
+	if _, err := storage.AddTask(models.Task{Title: "Second", Status: models.StatusTodo}); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	// Files that are not task files are ignored
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Tasks"), 0644); err != nil {
+		t.Fatalf("Failed to write README: %v", err)
+	}
+
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != 1 || tasks[1].ID != 2 {
+		t.Fatalf("Expected tasks 1 and 2, got %v", tasks)
+	}
+
+	if err := storage.DeleteTask(1); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	if _, err := storage.GetTaskByID(1); err == nil {
+		t.Error("Expected error getting deleted task, got nil")
+	}
+	if err := storage.UpdateTask(models.Task{ID: 9, Title: "Missing"}); err == nil {
+		t.Error("Expected error updating missing task, got nil")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "3-broken.md"), []byte("# No front matter"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := storage.GetTasks(); err == nil {
+		t.Error("Expected error for a file without front matter, got nil")
+	}
+}
+
+func TestMarkdownStorageRejectsUnstorableTitles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "markdown-title-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	storage, err := NewMarkdownStorage(filepath.Join(tempDir, "tasks"))
+	if err != nil {
+		t.Fatalf("Failed to create markdown storage: %v", err)
+	}
+
+	task, err := storage.AddTask(models.Task{Title: "Keep", Status: models.StatusTodo})
+	if err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	for _, title := range []string{"", "  ", "First line\nSecond line"} {
+		if _, err := storage.AddTask(models.Task{Title: title, Status: models.StatusTodo}); err == nil {
+			t.Errorf("Expected error adding a task titled %q, got nil", title)
+		}
+
+		renamed := task
+		renamed.Title = title
+		if err := storage.UpdateTask(renamed); err == nil {
+			t.Errorf("Expected error updating a task to title %q, got nil", title)
+		}
+
+		if err := storage.ReplaceTasks([]models.Task{task, {ID: 2, Title: title}}); err == nil {
+			t.Errorf("Expected error replacing tasks with title %q, got nil", title)
+		}
+	}
+
+	// The store stays readable and unchanged
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Expected the store to stay readable, got %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Keep" {
+		t.Errorf("Expected only task 'Keep', got %v", tasks)
+	}
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Replacer is implemented by storages that can replace all of their tasks at
+// once, keeping the IDs of the given tasks
+type Replacer interface {
+	// ReplaceTasks replaces every stored task with the given ones
+	ReplaceTasks(tasks []models.Task) error
+}
+
+// MigrateResult describes a finished migration
+type MigrateResult struct {
+	// Count is the number of tasks copied
+	Count int
+	// Checksum is the checksum of the source tasks
+	Checksum string
+	// Changed lists the IDs of tasks the destination stored differently,
+	// e.g. because the backend does not keep every attribute
+	Changed []int
+}
+
+// Migrate copies every task of src into dst, keeping IDs, timestamps and
+// status history, then reads dst back to verify the copy. A destination that
+// already holds tasks is refused unless force is set, in which case its
+// tasks are replaced.
+func Migrate(dst, src Storage, force bool) (MigrateResult, error) {
+	replacer, ok := dst.(Replacer)
+	if !ok {
+		return MigrateResult{}, errors.New("destination storage cannot keep task IDs")
+	}
+
+	existing, err := dst.GetTasks()
+	if err != nil {
+		return MigrateResult{}, fmt.Errorf("failed to read destination: %w", err)
+	}
+	if len(existing) > 0 && !force {
+		return MigrateResult{}, fmt.Errorf("destination already holds %d tasks, use --force to overwrite them", len(existing))
+	}
+
+	tasks, err := src.GetTasks()
+	if err != nil {
+		return MigrateResult{}, fmt.Errorf("failed to read source: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	for _, task := range tasks {
+		if task.ID <= 0 || seen[task.ID] {
+			return MigrateResult{}, fmt.Errorf("source has an invalid or duplicate task ID %d", task.ID)
+		}
+		seen[task.ID] = true
+	}
+
+	sorted := sortedByID(tasks)
+	checksum, err := Checksum(sorted)
+	if err != nil {
+		return MigrateResult{}, err
+	}
+
+	if err := replacer.ReplaceTasks(sorted); err != nil {
+		return MigrateResult{}, fmt.Errorf("failed to write destination: %w", err)
+	}
+
+	written, err := dst.GetTasks()
+	if err != nil {
+		return MigrateResult{}, fmt.Errorf("failed to read back destination: %w", err)
+	}
+	if len(written) != len(sorted) {
+		return MigrateResult{}, fmt.Errorf("verification failed: copied %d tasks but the destination holds %d", len(sorted), len(written))
+	}
+
+	changed, err := compareTasks(sorted, sortedByID(written))
+	if err != nil {
+		return MigrateResult{}, err
+	}
+
+	return MigrateResult{Count: len(sorted), Checksum: checksum, Changed: changed}, nil
+}
+
+// Checksum returns a SHA-256 checksum of the given tasks, independent of
+// their order
+func Checksum(tasks []models.Task) (string, error) {
+	hash := sha256.New()
+	for _, task := range sortedByID(tasks) {
+		data, err := json.Marshal(task)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode task %d: %w", task.ID, err)
+		}
+		hash.Write(data)
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// compareTasks returns the IDs of the tasks whose stored form differs
+// between want and got, both sorted by ID. It fails if a task is missing.
+func compareTasks(want, got []models.Task) ([]int, error) {
+	var changed []int
+	for i := range want {
+		if got[i].ID != want[i].ID {
+			return nil, fmt.Errorf("verification failed: task %d is missing from the destination", want[i].ID)
+		}
+
+		wantSum, err := Checksum(want[i : i+1])
+		if err != nil {
+			return nil, err
+		}
+		gotSum, err := Checksum(got[i : i+1])
+		if err != nil {
+			return nil, err
+		}
+		if wantSum != gotSum {
+			changed = append(changed, want[i].ID)
+		}
+	}
+	return changed, nil
+}
+
+// sortedByID returns a copy of tasks sorted by ID
+func sortedByID(tasks []models.Task) []models.Task {
+	sorted := make([]models.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestMigrate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "migrate-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// todo.txt keeps dates only, so use a timestamp every backend can round-trip
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	src := NewMockStorage()
+	src.AddTask(models.Task{Title: "First", Status: models.StatusTodo, Label: "bug", CreatedAt: created, UpdatedAt: created})
+	src.AddTask(models.Task{Title: "Second", Status: models.StatusTodo, Label: "task", CreatedAt: created, UpdatedAt: created})
+	src.AddTask(models.Task{Title: "Third", Status: models.StatusDone, Label: "task", CreatedAt: created, UpdatedAt: created})
+	src.DeleteTask(2)
+
+	// Test copying into every backend keeps IDs and timestamps
+	for _, backend := range Backends() {
+		dst, err := Open(backend, filepath.Join(tempDir, backend, FileName(backend)), models.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to open %s storage: %v", backend, err)
+		}
+
+		result, err := Migrate(dst, src, false)
+		if err != nil {
+			t.Fatalf("Failed to migrate to %s storage: %v", backend, err)
+		}
+
+		if result.Count != 2 {
+			t.Errorf("Expected 2 tasks migrated to %s, got %d", backend, result.Count)
+		}
+
+		if Lossless(backend) && len(result.Changed) != 0 {
+			t.Errorf("Expected no changed tasks in %s storage, got %v", backend, result.Changed)
+		}
+
+		task, err := dst.GetTaskByID(3)
+		if err != nil {
+			t.Fatalf("Failed to get migrated task from %s storage: %v", backend, err)
+		}
+
+		if task.Title != "Third" || task.Status != models.StatusDone {
+			t.Errorf("Expected task 'Third' to be done in %s storage, got %q %s", backend, task.Title, task.Status)
+		}
+
+		if !task.CreatedAt.Equal(created) {
+			t.Errorf("Expected created time %s in %s storage, got %s", created, backend, task.CreatedAt)
+		}
+
+		// New tasks continue after the highest migrated ID
+		added, err := dst.AddTask(models.Task{Title: "Fourth", Status: models.StatusTodo, Label: "task"})
+		if err != nil {
+			t.Fatalf("Failed to add task to %s storage: %v", backend, err)
+		}
+
+		if added.ID != 4 {
+			t.Errorf("Expected new task ID 4 in %s storage, got %d", backend, added.ID)
+		}
+
+		// Test refusing a destination that already holds tasks unless forced
+		if _, err := Migrate(dst, src, false); err == nil {
+			t.Errorf("Expected error when migrating into non-empty %s storage, got nil", backend)
+		}
+
+		if _, err := Migrate(dst, src, true); err != nil {
+			t.Fatalf("Failed to force migration into %s storage: %v", backend, err)
+		}
+
+		if _, err := dst.GetTaskByID(4); err == nil {
+			t.Errorf("Expected forced migration to replace the tasks of %s storage", backend)
+		}
+	}
+
+	// Test refusing duplicate IDs in the source
+	duplicated := NewMockStorage()
+	duplicated.tasks = []models.Task{{ID: 1, Title: "A"}, {ID: 1, Title: "B"}}
+
+	if _, err := Migrate(NewMockStorage(), duplicated, false); err == nil {
+		t.Error("Expected error when migrating duplicate IDs, got nil")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	first := models.Task{ID: 1, Title: "First"}
+	second := models.Task{ID: 2, Title: "Second"}
+
+	forward, err := Checksum([]models.Task{first, second})
+	if err != nil {
+		t.Fatalf("Failed to compute checksum: %v", err)
+	}
+
+	backward, err := Checksum([]models.Task{second, first})
+	if err != nil {
+		t.Fatalf("Failed to compute checksum: %v", err)
+	}
+
+	if forward != backward {
+		t.Error("Expected checksum to ignore task order")
+	}
+
+	second.Title = "Changed"
+	changed, err := Checksum([]models.Task{first, second})
+	if err != nil {
+		t.Fatalf("Failed to compute checksum: %v", err)
+	}
+
+	if changed == forward {
+		t.Error("Expected checksum to change with the task contents")
+	}
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// MilestoneStorage defines the interface for milestone storage operations
+type MilestoneStorage interface {
+	// GetMilestones returns all milestones
+	GetMilestones() ([]models.Milestone, error)
+
+	// GetMilestone retrieves a milestone by its name
+	GetMilestone(name string) (models.Milestone, error)
+
+	// SaveMilestone adds a milestone or replaces the one with the same name
+	SaveMilestone(milestone models.Milestone) error
+
+	// DeleteMilestone removes a milestone by name
+	DeleteMilestone(name string) error
+}
+
+// CopyMilestones saves every milestone of src into dst and returns how many
+// were copied
+func CopyMilestones(dst, src MilestoneStorage) (int, error) {
+	milestones, err := src.GetMilestones()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read milestones: %w", err)
+	}
+
+	for _, milestone := range milestones {
+		if err := dst.SaveMilestone(milestone); err != nil {
+			return 0, fmt.Errorf("failed to copy milestone %s: %w", milestone.Name, err)
+		}
+	}
+
+	return len(milestones), nil
+}
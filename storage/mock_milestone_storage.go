@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// MockMilestoneStorage implements the MilestoneStorage interface for testing
+type MockMilestoneStorage struct {
+	milestones []models.Milestone
+	mutex      sync.RWMutex
+}
+
+// NewMockMilestoneStorage creates a new MockMilestoneStorage instance
+func NewMockMilestoneStorage() *MockMilestoneStorage {
+	return &MockMilestoneStorage{
+		milestones: []models.Milestone{},
+	}
+}
+
+// GetMilestones returns all milestones
+func (s *MockMilestoneStorage) GetMilestones() ([]models.Milestone, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.milestones, nil
+}
+
+// GetMilestone retrieves a milestone by its name
+func (s *MockMilestoneStorage) GetMilestone(name string) (models.Milestone, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, m := range s.milestones {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+
+	return models.Milestone{}, errors.New("milestone not found")
+}
+
+// SaveMilestone adds a milestone or replaces the one with the same name
+func (s *MockMilestoneStorage) SaveMilestone(milestone models.Milestone) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, m := range s.milestones {
+		if m.Name == milestone.Name {
+			s.milestones[i] = milestone
+			return nil
+		}
+	}
+
+	s.milestones = append(s.milestones, milestone)
+	return nil
+}
+
+// DeleteMilestone removes a milestone by name
+func (s *MockMilestoneStorage) DeleteMilestone(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, m := range s.milestones {
+		if m.Name == name {
+			s.milestones = append(s.milestones[:i], s.milestones[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New("milestone not found")
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestMockMilestoneStorage(t *testing.T) {
+	storage := NewMockMilestoneStorage()
+
+	if err := storage.SaveMilestone(models.Milestone{Name: "v1.0"}); err != nil {
+		t.Fatalf("Failed to save milestone: %v", err)
+	}
+
+	if err := storage.SaveMilestone(models.Milestone{Name: "v1.0", Description: "Release"}); err != nil {
+		t.Fatalf("Failed to replace milestone: %v", err)
+	}
+
+	milestones, _ := storage.GetMilestones()
+	if len(milestones) != 1 || milestones[0].Description != "Release" {
+		t.Errorf("Expected one replaced milestone, got %v", milestones)
+	}
+
+	if err := storage.DeleteMilestone("v1.0"); err != nil {
+		t.Fatalf("Failed to delete milestone: %v", err)
+	}
+
+	if _, err := storage.GetMilestone("v1.0"); err == nil {
+		t.Error("Expected error when getting deleted milestone, got nil")
+	}
+
+	if err := storage.DeleteMilestone("v1.0"); err == nil {
+		t.Error("Expected error when deleting non-existent milestone, got nil")
+	}
+}
@@ -102,3 +102,12 @@ func (s *MockStorage) GetTaskByID(id int) (models.Task, error) {
 
 	return models.Task{}, errors.New("task not found")
 }
+
+// ReplaceTasks replaces every task
+func (s *MockStorage) ReplaceTasks(tasks []models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tasks = append([]models.Task{}, tasks...)
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// Supported storage backends
+const (
+	BackendJSON     = "json"
+	BackendYAML     = "yaml"
+	BackendTodoTxt  = "todotxt"
+	BackendMarkdown = "markdown"
+)
+
+// Backends returns the names of the supported storage backends
+func Backends() []string {
+	return []string{BackendJSON, BackendYAML, BackendTodoTxt, BackendMarkdown}
+}
+
+// FileName returns the default name of the tasks file, or directory, of a backend
+func FileName(backend string) string {
+	switch backend {
+	case BackendYAML:
+		return "tasks.yaml"
+	case BackendTodoTxt:
+		return "todo.txt"
+	case BackendMarkdown:
+		return "tasks"
+	default:
+		return "tasks.json"
+	}
+}
+
+// Lossless reports whether a backend keeps every attribute of a task
+func Lossless(backend string) bool {
+	return backend != BackendTodoTxt
+}
+
+// ParseURI splits a storage URI of the form <backend>://<path>, e.g.
+// yaml://~/tasks.yaml, into the backend and the path
+func ParseURI(uri string) (string, string, error) {
+	backend, path, ok := strings.Cut(uri, "://")
+	if !ok || path == "" {
+		return "", "", fmt.Errorf("invalid storage URI %q, expected <backend>://<path>", uri)
+	}
+
+	for _, name := range Backends() {
+		if backend == name {
+			return backend, path, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unknown storage backend: %s (available: %s)", backend, strings.Join(Backends(), ", "))
+}
+
+// Open creates the storage for the given backend at path. The workflow maps
+// the statuses of backends that do not store them verbatim.
+func Open(backend, path string, workflow *models.Workflow) (Storage, error) {
+	return OpenEncrypted(backend, path, workflow, nil)
+}
+
+// OpenEncrypted creates the storage for the given backend at path, keeping
+// its file encrypted with c. A nil cipher opens the storage unencrypted.
+func OpenEncrypted(backend, path string, workflow *models.Workflow, c *Cipher) (Storage, error) {
+	switch backend {
+	case "", BackendJSON:
+		return newJSONStorage(path, c)
+	case BackendYAML:
+		return newYAMLStorage(path, c)
+	case BackendTodoTxt:
+		return newTodoTxtStorage(path, workflow, c)
+	case BackendMarkdown:
+		if c != nil {
+			return nil, errors.New("the markdown backend keeps task titles in file names and cannot be encrypted")
+		}
+		return NewMarkdownStorage(path)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", backend)
+	}
+}
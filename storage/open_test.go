@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestOpen(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "open-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, backend := range Backends() {
+		if _, err := Open(backend, filepath.Join(tempDir, backend, FileName(backend)), models.DefaultWorkflow()); err != nil {
+			t.Errorf("Failed to open %s storage: %v", backend, err)
+		}
+	}
+
+	if _, err := Open("xml", filepath.Join(tempDir, "tasks.xml"), models.DefaultWorkflow()); err == nil {
+		t.Error("Expected error for unknown backend, got nil")
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	backend, path, err := ParseURI("yaml:///tmp/tasks.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse URI: %v", err)
+	}
+
+	if backend != BackendYAML || path != "/tmp/tasks.yaml" {
+		t.Errorf("Expected yaml and /tmp/tasks.yaml, got %s and %s", backend, path)
+	}
+
+	for _, uri := range []string{"/tmp/tasks.json", "json://", "xml:///tmp/tasks.xml"} {
+		if _, _, err := ParseURI(uri); err == nil {
+			t.Errorf("Expected error for URI %q, got nil", uri)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mstgnz/cli-task-manager/formats"
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// TodoTxtStorage implements the Storage interface using a todo.txt file.
+// Task IDs are line numbers; deleted tasks leave an empty line behind so
+// that the IDs of the other tasks do not change. Attributes todo.txt has no
+// syntax for are kept in the tags of extended lines.
+type TodoTxtStorage struct {
+	filePath string
+	format   *formats.TodoTxt
+	cipher   *Cipher
+	mutex    sync.RWMutex
+}
+
+// NewTodoTxtStorage creates a new TodoTxtStorage instance
+func NewTodoTxtStorage(filePath string, workflow *models.Workflow) (*TodoTxtStorage, error) {
+	return newTodoTxtStorage(filePath, workflow, nil)
+}
+
+// newTodoTxtStorage creates a TodoTxtStorage encrypting its file with c, if set
+func newTodoTxtStorage(filePath string, workflow *models.Workflow, c *Cipher) (*TodoTxtStorage, error) {
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Create file if it doesn't exist
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		if err := writeFile(filePath, nil, c); err != nil {
+			return nil, fmt.Errorf("failed to create file: %w", err)
+		}
+	}
+
+	return &TodoTxtStorage{
+		filePath: filePath,
+		format:   formats.NewExtendedTodoTxt(workflow),
+		cipher:   c,
+	}, nil
+}
+
+// GetTasks returns all tasks from the todo.txt file
+func (s *TodoTxtStorage) GetTasks() ([]models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []models.Task
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		task, err := s.parseLine(i, line)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// AddTask appends a new task to the todo.txt file
+func (s *TodoTxtStorage) AddTask(task models.Task) (models.Task, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	task.ID = len(lines) + 1
+	lines = append(lines, s.format.FormatLine(task))
+
+	if err := s.writeLines(lines); err != nil {
+		return models.Task{}, err
+	}
+
+	return task, nil
+}
+
+// UpdateTask replaces the line of an existing task
+func (s *TodoTxtStorage) UpdateTask(task models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return err
+	}
+
+	if !hasLine(lines, task.ID) {
+		return errors.New("task not found")
+	}
+	lines[task.ID-1] = s.format.FormatLine(task)
+
+	return s.writeLines(lines)
+}
+
+// DeleteTask empties the line of a task
+func (s *TodoTxtStorage) DeleteTask(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return err
+	}
+
+	if !hasLine(lines, id) {
+		return errors.New("task not found")
+	}
+	lines[id-1] = ""
+
+	return s.writeLines(lines)
+}
+
+// GetTaskByID retrieves a task by its line number
+func (s *TodoTxtStorage) GetTaskByID(id int) (models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	lines, err := s.readLines()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	if !hasLine(lines, id) {
+		return models.Task{}, errors.New("task not found")
+	}
+
+	return s.parseLine(id-1, lines[id-1])
+}
+
+// ReplaceTasks rewrites the todo.txt file, placing each task on the line of
+// its ID and leaving the lines of missing IDs empty
+func (s *TodoTxtStorage) ReplaceTasks(tasks []models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	maxID := 0
+	for _, task := range tasks {
+		if task.ID <= 0 {
+			return fmt.Errorf("invalid task ID %d", task.ID)
+		}
+		if task.ID > maxID {
+			maxID = task.ID
+		}
+	}
+
+	lines := make([]string, maxID)
+	for _, task := range tasks {
+		lines[task.ID-1] = s.format.FormatLine(task)
+	}
+
+	return s.writeLines(lines)
+}
+
+// parseLine parses the line at the given index into a task
+func (s *TodoTxtStorage) parseLine(index int, line string) (models.Task, error) {
+	task, err := s.format.ParseLine(line)
+	if err != nil {
+		return models.Task{}, fmt.Errorf("%s:%d: %w", s.filePath, index+1, err)
+	}
+	task.ID = index + 1
+	return task, nil
+}
+
+// hasLine reports whether the line of a task exists and is not empty
+func hasLine(lines []string, id int) bool {
+	return id >= 1 && id <= len(lines) && strings.TrimSpace(lines[id-1]) != ""
+}
+
+// readLines reads the lines of the todo.txt file
+func (s *TodoTxtStorage) readLines() ([]string, error) {
+	data, err := readFile(s.filePath, s.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	content := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if content == "" {
+		return nil, nil
+	}
+
+	return strings.Split(content, "\n"), nil
+}
+
+// writeLines writes the lines to the todo.txt file
+func (s *TodoTxtStorage) writeLines(lines []string) error {
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+
+	if err := writeFile(s.filePath, []byte(content), s.cipher); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
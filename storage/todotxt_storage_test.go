@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+func TestTodoTxtStorage(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "todotxt-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir) // Clean up after test
+
+	filePath := filepath.Join(tempDir, "todo.txt")
+	storage, err := NewTodoTxtStorage(filePath, models.DefaultWorkflow())
+	if err != nil {
+		t.Fatalf("Failed to create todo.txt storage: %v", err)
+	}
+
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	for _, title := range []string{"First", "Second", "Third"} {
+		task := models.Task{Title: title, Label: "test", Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created}
+		if _, err := storage.AddTask(task); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	// Test updating a task
+	task, err := storage.GetTaskByID(2)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	task.Status = models.StatusInProgress
+	if err := storage.UpdateTask(task); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	// Test deleting a task keeps the IDs of the other tasks
+	if err := storage.DeleteTask(1); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != 2 || tasks[0].ID != 2 || tasks[1].ID != 3 {
+		t.Fatalf("Expected tasks 2 and 3, got %v", tasks)
+	}
+
+	if tasks[0].Status != models.StatusInProgress {
+		t.Errorf("Expected task 2 to be in progress, got %s", tasks[0].Status)
+	}
+
+	if _, err := storage.GetTaskByID(1); err == nil {
+		t.Error("Expected error getting deleted task, got nil")
+	}
+	if err := storage.DeleteTask(1); err == nil {
+		t.Error("Expected error deleting deleted task, got nil")
+	}
+	if err := storage.UpdateTask(models.Task{ID: 9, Title: "Missing"}); err == nil {
+		t.Error("Expected error updating missing task, got nil")
+	}
+
+	added, err := storage.AddTask(models.Task{Title: "Fourth", Status: models.StatusTodo, CreatedAt: created})
+	if err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if added.ID != 4 {
+		t.Errorf("Expected new task ID to be 4, got %d", added.ID)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"",
+		"2024-03-01 Second +test status:in-progress",
+		"2024-03-01 Third +test",
+		"2024-03-01 Fourth",
+		"",
+	}, "\n")
+	if string(data) != expected {
+		t.Errorf("Expected file:\n%q\ngot:\n%q", expected, string(data))
+	}
+}
+
+func TestTodoTxtStorageKeepsAllAttributes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "todotxt-storage-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	storage, err := NewTodoTxtStorage(filepath.Join(tempDir, "todo.txt"), models.DefaultWorkflow())
+	if err != nil {
+		t.Fatalf("Failed to create todo.txt storage: %v", err)
+	}
+
+	created := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	started := time.Date(2024, 3, 2, 9, 0, 0, 0, time.Local)
+	stopped := started.Add(90 * time.Minute)
+
+	task, err := storage.AddTask(models.Task{Title: "Call ACME", Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created})
+	if err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	task.Description = "Ask about the invoice: 50% paid"
+	task.Recurrence = "weekly:mon,thu"
+	task.SetStatus(models.StatusInProgress, started)
+	task.TimeEntries = []models.TimeEntry{{Start: started, End: &stopped}, {Start: stopped.Add(time.Hour)}}
+	task.UpdatedAt = stopped.Add(time.Hour)
+	if err := storage.UpdateTask(task); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	stored, err := storage.GetTaskByID(task.ID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+
+	if stored.Description != task.Description {
+		t.Errorf("Expected description %q, got %q", task.Description, stored.Description)
+	}
+	if stored.Recurrence != task.Recurrence {
+		t.Errorf("Expected recurrence %q, got %q", task.Recurrence, stored.Recurrence)
+	}
+	if !stored.UpdatedAt.Equal(task.UpdatedAt) {
+		t.Errorf("Expected updated_at %s, got %s", task.UpdatedAt, stored.UpdatedAt)
+	}
+	if len(stored.History) != 1 || stored.History[0].To != models.StatusInProgress || !stored.History[0].At.Equal(started) {
+		t.Errorf("Expected the status change to be kept, got %v", stored.History)
+	}
+	if len(stored.TimeEntries) != 2 || stored.TimeEntries[0].End == nil || !stored.TimeEntries[0].End.Equal(stopped) || !stored.TimeEntries[1].Running() {
+		t.Errorf("Expected a stopped and a running time entry, got %v", stored.TimeEntries)
+	}
+	if len(stored.Fields) != 0 {
+		t.Errorf("Expected no custom fields, got %v", stored.Fields)
+	}
+}
+
+func TestTodoTxtStorageKeepsTitlesAndTimes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "todotxt-title-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	storage, err := NewTodoTxtStorage(filepath.Join(tempDir, "todo.txt"), models.DefaultWorkflow())
+	if err != nil {
+		t.Fatalf("Failed to create todo.txt storage: %v", err)
+	}
+
+	created := time.Date(2024, 3, 1, 9, 15, 30, 0, time.Local)
+	titles := []string{
+		"see:docs +urgent @home",
+		"x marks the spot",
+		"(A) 2024-03-01 first",
+		`\escaped word`,
+	}
+
+	for _, title := range titles {
+		if _, err := storage.AddTask(models.Task{Title: title, Status: models.StatusTodo, CreatedAt: created, UpdatedAt: created}); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	tasks, err := storage.GetTasks()
+	if err != nil {
+		t.Fatalf("Failed to get tasks: %v", err)
+	}
+
+	if len(tasks) != len(titles) {
+		t.Fatalf("Expected %d tasks, got %d", len(titles), len(tasks))
+	}
+	for i, task := range tasks {
+		if task.Title != titles[i] {
+			t.Errorf("Expected title %q, got %q", titles[i], task.Title)
+		}
+		if task.Label != "" || len(task.Fields) != 0 {
+			t.Errorf("Expected no metadata parsed from the title, got label %q and fields %v", task.Label, task.Fields)
+		}
+		if !task.CreatedAt.Equal(created) || !task.UpdatedAt.Equal(created) {
+			t.Errorf("Expected created and updated at %s, got %s and %s", created, task.CreatedAt, task.UpdatedAt)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mstgnz/cli-task-manager/models"
+)
+
+// YAMLStorage implements the Storage interface using a YAML file
+type YAMLStorage struct {
+	filePath string
+	cipher   *Cipher
+	mutex    sync.RWMutex
+}
+
+// NewYAMLStorage creates a new YAMLStorage instance
+func NewYAMLStorage(filePath string) (*YAMLStorage, error) {
+	return newYAMLStorage(filePath, nil)
+}
+
+// newYAMLStorage creates a YAMLStorage encrypting its file with c, if set
+func newYAMLStorage(filePath string, c *Cipher) (*YAMLStorage, error) {
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Create file if it doesn't exist
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		if err := writeFile(filePath, []byte("[]\n"), c); err != nil {
+			return nil, fmt.Errorf("failed to create file: %w", err)
+		}
+	}
+
+	return &YAMLStorage{
+		filePath: filePath,
+		cipher:   c,
+	}, nil
+}
+
+// GetTasks returns all tasks from the YAML file
+func (s *YAMLStorage) GetTasks() ([]models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, err := readFile(s.filePath, s.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var tasks []models.Task
+	if err := yaml.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// AddTask adds a new task to the YAML file
+func (s *YAMLStorage) AddTask(task models.Task) (models.Task, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks, err := s.readTasks()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	// Generate a new ID
+	maxID := 0
+	for _, t := range tasks {
+		if t.ID > maxID {
+			maxID = t.ID
+		}
+	}
+	task.ID = maxID + 1
+
+	tasks = append(tasks, task)
+
+	if err := s.writeTasks(tasks); err != nil {
+		return models.Task{}, err
+	}
+
+	return task, nil
+}
+
+// UpdateTask updates an existing task in the YAML file
+func (s *YAMLStorage) UpdateTask(task models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks, err := s.readTasks()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, t := range tasks {
+		if t.ID == task.ID {
+			tasks[i] = task
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return errors.New("task not found")
+	}
+
+	return s.writeTasks(tasks)
+}
+
+// DeleteTask removes a task by ID from the YAML file
+func (s *YAMLStorage) DeleteTask(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks, err := s.readTasks()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	var updatedTasks []models.Task
+	for _, t := range tasks {
+		if t.ID != id {
+			updatedTasks = append(updatedTasks, t)
+		} else {
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.New("task not found")
+	}
+
+	return s.writeTasks(updatedTasks)
+}
+
+// GetTaskByID retrieves a task by its ID
+func (s *YAMLStorage) GetTaskByID(id int) (models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	tasks, err := s.readTasks()
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	for _, t := range tasks {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+
+	return models.Task{}, errors.New("task not found")
+}
+
+// ReplaceTasks replaces every task in the YAML file
+func (s *YAMLStorage) ReplaceTasks(tasks []models.Task) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.writeTasks(tasks)
+}
+
+// readTasks reads all tasks from the YAML file
+func (s *YAMLStorage) readTasks() ([]models.Task, error) {
+	data, err := readFile(s.filePath, s.cipher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var tasks []models.Task
+	if err := yaml.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// writeTasks writes all tasks to the YAML file
+func (s *YAMLStorage) writeTasks(tasks []models.Task) error {
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(tasks); err != nil {
+		return fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+
+	if err := writeFile(s.filePath, buf.Bytes(), s.cipher); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}